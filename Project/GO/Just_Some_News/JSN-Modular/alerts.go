@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/alert"
+	"jsn-modular/internal/store"
+)
+
+const alertsUsage = `사용법:
+  jsn alerts test [--webhook 이름]
+  jsn alerts check [--since 시각] [--limit N]
+
+test 는 시험 알림을 웹훅으로 보냅니다 (저장소에 기록하지 않음). --webhook 을 생략하면 모든 웹훅에 보냅니다.
+check 는 저장된 최근 기사에 알림 규칙을 적용해 알림이 갈 기사를 출력합니다. 보내거나 기록하지 않습니다.
+규칙과 웹훅은 설정 파일의 alerts 항목에 둡니다 (scripts/jsn.example.yaml 참고).`
+
+// runAlerts 는 jsn alerts 하위 명령을 실행합니다.
+func runAlerts(cfg *config.Config, args []string) int {
+	fs := newFlagSet("alerts", alertsUsage)
+	hook := fs.String("webhook", "", "test: 보낼 웹훅 이름")
+	var since time.Time
+	fs.Var(timeValue{&since}, "since", "check: 이 `시각` 이후 발행된 기사만")
+	limit := fs.Int("limit", 100, "check: 최근 기사 최대 `건수`")
+	args, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(args) != 1 {
+		return usageError(fs, "하위 명령이 필요함 (test, check)")
+	}
+
+	n, err := alert.New(cfg.Alerts)
+	if err != nil {
+		return configError(err)
+	}
+	switch args[0] {
+	case "test":
+		hooks := n.Webhooks()
+		if *hook != "" {
+			if !slices.Contains(hooks, *hook) {
+				return usageError(fs, "설정에 없는 웹훅: %q", *hook)
+			}
+			hooks = []string{*hook}
+		}
+		if len(hooks) == 0 {
+			return usageError(fs, "설정에 웹훅이 없음 (alerts.webhooks)")
+		}
+		return testAlerts(n, hooks)
+	case "check":
+		if *limit <= 0 {
+			return usageError(fs, "--limit 은 1 이상이어야 함")
+		}
+		return checkAlerts(cfg, n, store.Query{Since: since, Limit: *limit})
+	}
+	return usageError(fs, "알 수 없는 하위 명령: %q", args[0])
+}
+
+func testAlerts(n *alert.Notifier, hooks []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	msg := alert.Message{
+		Rules:    []string{"test"},
+		Matches:  []string{"CVE-2024-0001"},
+		Severity: "high",
+		Source:   "jsn",
+		Title:    "JSN 알림 시험 전송 (CVE-2024-0001)",
+		Link:     "https://example.com/jsn/alert-test",
+		PubDate:  time.Now().UTC().Truncate(time.Second),
+		Summary:  "jsn alerts test 로 보낸 시험 알림입니다. 이 메시지가 보이면 웹훅 설정이 올바릅니다.",
+		Test:     true,
+	}
+	code := exitOK
+	for _, h := range hooks {
+		attempts, err := n.Send(ctx, h, msg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: 실패 (%d회 시도): %v\n", h, attempts, err)
+			code = exitError
+			continue
+		}
+		fmt.Printf("%s: 전송 성공 (%d회 시도)\n", h, attempts)
+	}
+	return code
+}
+
+func checkAlerts(cfg *config.Config, n *alert.Notifier, q store.Query) int {
+	st, err := openStore(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer st.Close()
+
+	articles, err := st.Query(context.Background(), q)
+	if err != nil {
+		return fail(err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PUBLISHED\tSEVERITY\tWEBHOOK\tRULES\tMATCHES\tTITLE")
+	matched := 0
+	for _, a := range articles {
+		for _, m := range n.Match(a) {
+			matched++
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", a.PubDate.Local().Format("2006-01-02 15:04"),
+				m.Message.Severity, m.Webhook, strings.Join(m.Message.Rules, ","), strings.Join(m.Message.Matches, ","), a.Title)
+		}
+	}
+	w.Flush()
+	fmt.Printf("\n기사 %d건 중 알림 %d건\n", len(articles), matched)
+	return exitOK
+}
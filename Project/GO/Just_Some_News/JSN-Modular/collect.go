@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/logger"
+	"jsn-modular/internal/rss"
+)
+
+const collectUsage = `사용법: jsn collect [--feed 이름[,이름...]]
+
+설정의 활성 피드를 한 번 수집합니다. 로그는 표준 출력과 logs/jsn.log 에 남깁니다.
+--feed 로 이름을 주면 그 피드만 수집합니다 (비활성 피드도 포함).
+
+종료 코드: 0 모두 성공(304 포함), 3 저장소 연결 실패, 4 모든 피드 실패, 5 일부 피드 실패
+서킷 오픈으로 건너뛴 피드도 실패로 셉니다.
+설정에 alerts 규칙이 있으면 신규 기사를 웹훅으로 알립니다 (알림 실패는 종료 코드에 반영하지 않음).`
+
+// runCollect 는 jsn collect 를 실행합니다.
+func runCollect(cfg *config.Config, args []string) int {
+	fs := newFlagSet("collect", collectUsage)
+	only := fs.String("feed", "", "수집할 피드 이름 (쉼표로 구분)")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+	if *only != "" {
+		var feeds []config.Feed
+		for _, name := range strings.Split(*only, ",") {
+			i := slices.IndexFunc(cfg.Feeds, func(f config.Feed) bool { return f.Name == strings.TrimSpace(name) })
+			if i < 0 {
+				return usageError(fs, "설정에 없는 피드: %q", name)
+			}
+			f := cfg.Feeds[i]
+			f.Enabled = true
+			feeds = append(feeds, f)
+		}
+		cfg.Feeds = feeds
+	}
+
+	// 1. 로거 설정
+	logFile := logger.Setup()
+	defer logFile.Close()
+
+	// 2. 저장소 초기화 (DSN 스킴으로 MariaDB / SQLite / 메모리 선택)
+	st, err := openStore(cfg)
+	if err != nil {
+		log.Printf("인프라 초기화 실패: %v", err)
+		return exitCode(err)
+	}
+	defer st.Close()
+
+	// 3. 뉴스 수집 실행 (SIGINT/SIGTERM 이면 진행 중인 요청과 저장소 작업을 취소)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	run := rss.Collect(ctx, st, cfg)
+
+	// 4. 실행 결과를 종료 코드로 변환
+	code = collectExitCode(run)
+	switch code {
+	case exitOK:
+		log.Println(">>> 프로그램 정상 종료.")
+	default:
+		log.Printf(">>> 피드 %d개 중 %d개 실패:\n%v", len(run.Feeds), len(run.Failed()), run.Err())
+		log.Printf(">>> 프로그램 종료 (종료 코드 %d).", code)
+	}
+	return code
+}
+
+// collectExitCode 는 수집 결과를 종료 코드로 바꿉니다. 종료 신호로 취소된 실행은 실패로 보지 않습니다.
+func collectExitCode(run rss.RunResult) int {
+	switch {
+	case run.Canceled:
+		return exitOK
+	case run.AllFailed():
+		return exitAllFailed
+	case len(run.Failed()) > 0:
+		return exitPartial
+	}
+	return exitOK
+}
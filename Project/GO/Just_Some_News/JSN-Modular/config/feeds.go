@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AddFeed 는 c 를 읽어 온 YAML 설정 파일의 feeds 목록에 f 를 추가합니다.
+// yaml.Node 단위로 고치므로 파일의 주석과 다른 키의 배치는 그대로 남습니다.
+func AddFeed(c *Config, f Feed) error {
+	next := *c
+	next.Feeds = append(slices.Clone(c.Feeds), f)
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	return editFeeds(c, func(seq *yaml.Node) error {
+		seq.Content = append(seq.Content, feedNode(f))
+		return nil
+	})
+}
+
+// RemoveFeed 는 YAML 설정 파일의 feeds 목록에서 name 피드를 뺍니다. 수집된 기사는 지우지 않습니다.
+func RemoveFeed(c *Config, name string) error {
+	return editFeeds(c, func(seq *yaml.Node) error {
+		for i, item := range seq.Content {
+			var f rawFeed
+			if err := item.Decode(&f); err == nil && f.Name == name {
+				seq.Content = slices.Delete(seq.Content, i, i+1)
+				return nil
+			}
+		}
+		return fmt.Errorf("피드를 찾을 수 없음: %q", name)
+	})
+}
+
+// editFeeds 는 설정 파일의 feeds 시퀀스 노드를 fn 으로 고친 뒤 원자적으로 다시 씁니다.
+// 파일에 feeds 키가 없으면 현재 적용 중인 목록(기본값)으로 시퀀스를 만든 뒤 고칩니다.
+func editFeeds(c *Config, fn func(seq *yaml.Node) error) error {
+	path := c.File
+	if path == "" {
+		return fmt.Errorf("설정 파일이 없음: --config 또는 $%s 로 YAML 설정 파일을 지정하세요", envConfigFile)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("설정 파일 %s: YAML 파일만 수정할 수 있음 (TOML 은 직접 편집하세요)", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("설정 파일 %s 파싱 실패: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		// 빈 파일이나 주석만 있는 파일
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("설정 파일 %s: 최상위가 맵이 아님", path)
+	}
+
+	var seq *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "feeds" {
+			seq = root.Content[i+1]
+		}
+	}
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		if seq != nil && !(seq.Kind == yaml.ScalarNode && seq.Tag == "!!null") {
+			return fmt.Errorf("설정 파일 %s: feeds 가 목록이 아님", path)
+		}
+		list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, f := range c.Feeds {
+			list.Content = append(list.Content, feedNode(f))
+		}
+		if seq != nil {
+			*seq = *list
+		} else {
+			seq = list
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "feeds"}, seq)
+		}
+	}
+	if err := fn(seq); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("설정 파일 직렬화 실패: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("설정 파일 직렬화 실패: %w", err)
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// feedNode 는 기본값과 다른 항목만 담은 피드 맵 노드를 만듭니다.
+func feedNode(f Feed) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	add := func(key, value, tag string) {
+		n.Content = append(n.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+		)
+	}
+	add("name", f.Name, "!!str")
+	add("url", f.URL, "!!str")
+	if !f.Enabled {
+		add("enabled", "false", "!!bool")
+	}
+	if f.Charset != "" {
+		add("charset", f.Charset, "!!str")
+	}
+	if f.FullText {
+		add("full_text", "true", "!!bool")
+	}
+	if f.Interval > 0 {
+		add("interval", f.Interval.String(), "!!str")
+	}
+	return n
+}
+
+// writeFileAtomic 은 같은 디렉터리의 임시 파일에 쓴 뒤 이름을 바꿔 원래 권한을 유지한 채 교체합니다.
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("설정 파일 확인 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("설정 파일 쓰기 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("설정 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("설정 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("설정 파일 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("설정 파일 쓰기 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix 는 설정 환경 변수 접두사입니다.
+const EnvPrefix = "JSN_"
+
+// envConfigFile 은 설정 파일 경로를 지정하는 환경 변수입니다 (--config 가 우선).
+const envConfigFile = EnvPrefix + "CONFIG"
+
+// searchPaths 는 --config, JSN_CONFIG 가 없을 때 차례로 찾는 설정 파일입니다.
+var searchPaths = []string{"jsn.yaml", "jsn.yml", "jsn.toml", "/etc/jsn/jsn.yaml", "/etc/jsn/jsn.toml"}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// field 는 설정 키 하나입니다 (예: "fetch.host_min_interval").
+type field struct {
+	Key    string
+	Help   string
+	Secret string // "true" 면 값 전체, "dsn" 이면 URL 의 비밀번호만 가림
+	Value  reflect.Value
+}
+
+// Env 는 키에 대응하는 환경 변수 이름입니다 (fetch.host_min_interval → JSN_FETCH_HOST_MIN_INTERVAL).
+func (f field) Env() string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(f.Key, ".", "_"))
+}
+
+// Flag 는 키에 대응하는 플래그 이름입니다 (fetch.host_min_interval → fetch-host-min-interval).
+func (f field) Flag() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(f.Key)
+}
+
+// fields 는 Config 의 스칼라 설정 키를 선언 순서대로 나열합니다. 목록(feeds)은 파일에서만 설정합니다.
+func fields(c *Config) []field {
+	var out []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fv := v.Field(i)
+			switch fv.Kind() {
+			case reflect.Struct:
+				walk(fv, prefix+name+".")
+			case reflect.String, reflect.Int, reflect.Int64, reflect.Bool:
+				out = append(out, field{Key: prefix + name, Help: sf.Tag.Get("help"), Secret: sf.Tag.Get("secret"), Value: fv})
+			}
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+	return out
+}
+
+// setField 는 문자열 값을 필드 타입에 맞게 변환해 설정합니다.
+func setField(v reflect.Value, s string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Int || v.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("지원하지 않는 타입: %s", v.Type())
+	}
+	return nil
+}
+
+// formatField 는 필드 값을 설정 파일/플래그 형식의 문자열로 바꿉니다.
+func formatField(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Loader 는 기본값 → 설정 파일 → JSN_* 환경 변수 → 명령행 플래그 순으로 설정을 겹쳐 읽은 뒤
+// 비밀 값을 파일 또는 systemd 자격 증명에서 채웁니다 (resolveSecrets).
+type Loader struct {
+	path  string
+	flags map[string]string // 명령행에서 지정한 키와 값
+}
+
+// NewLoader 는 fs 에 --config 와 모든 설정 키의 플래그를 등록합니다.
+// fs.Parse 뒤에 Load 를 호출합니다.
+func NewLoader(fs *flag.FlagSet) *Loader {
+	l := &Loader{flags: make(map[string]string)}
+	fs.StringVar(&l.path, "config", "", "설정 파일 경로 (YAML/TOML, 기본: $"+envConfigFile+" 또는 ./jsn.yaml)")
+	for _, f := range fields(Default()) {
+		fs.Var(&flagValue{key: f.Key, set: l.flags, def: f.Value}, f.Flag(), f.Help+" ($"+f.Env()+")")
+	}
+	return l
+}
+
+// flagValue 는 플래그 값을 검증만 하고 원문을 보관했다가 Load 에서 마지막에 적용합니다.
+type flagValue struct {
+	key string
+	set map[string]string
+	def reflect.Value
+}
+
+func (f *flagValue) String() string {
+	if f == nil || !f.def.IsValid() {
+		return ""
+	}
+	if v, ok := f.set[f.key]; ok {
+		return v
+	}
+	return formatField(f.def)
+}
+
+func (f *flagValue) Set(s string) error {
+	if err := setField(reflect.New(f.def.Type()).Elem(), s); err != nil {
+		return err
+	}
+	f.set[f.key] = s
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool { return f.def.Kind() == reflect.Bool }
+
+// Load 는 설정을 읽고 검증합니다. 잘못된 값은 모두 모아 하나의 오류로 반환합니다.
+func (l *Loader) Load() (*Config, error) {
+	cfg := Default()
+
+	path, explicit := l.path, l.path != ""
+	if path == "" {
+		path, explicit = os.Getenv(envConfigFile), os.Getenv(envConfigFile) != ""
+	}
+	if path == "" {
+		for _, p := range searchPaths {
+			if _, err := os.Stat(p); err == nil {
+				path = p
+				break
+			}
+		}
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			if explicit || !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		} else {
+			cfg.File = path
+		}
+	}
+
+	var errs []error
+	byKey := make(map[string]field)
+	known := map[string]bool{envConfigFile: true}
+	for _, f := range fields(cfg) {
+		byKey[f.Key] = f
+		known[f.Env()] = true
+		if v, ok := os.LookupEnv(f.Env()); ok {
+			if err := setField(f.Value, v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.Env(), err))
+			}
+		}
+	}
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, EnvPrefix) && !known[name] {
+			errs = append(errs, fmt.Errorf("%s: 알 수 없는 설정 환경 변수", name))
+		}
+	}
+	for key, v := range l.flags {
+		if err := setField(byKey[key].Value, v); err != nil {
+			errs = append(errs, fmt.Errorf("--%s: %w", byKey[key].Flag(), err))
+		}
+	}
+
+	errs = append(errs, resolveSecrets(cfg)...)
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("설정 오류:\n%w", err)
+	}
+	return cfg, nil
+}
+
+// loadFile 은 확장자로 형식을 골라 설정 파일을 cfg 위에 덮어씁니다. 모르는 키는 오류입니다.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && err != io.EOF {
+			return fmt.Errorf("설정 파일 %s 파싱 실패: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("설정 파일 %s 파싱 실패: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, k := range undecoded {
+				keys[i] = k.String()
+			}
+			return fmt.Errorf("설정 파일 %s: 알 수 없는 키: %s", path, strings.Join(keys, ", "))
+		}
+	default:
+		return fmt.Errorf("설정 파일 %s: 확장자로 형식을 알 수 없음 (.yaml, .yml, .toml)", path)
+	}
+	return nil
+}
+
+// rawFeed 는 Feed 의 기본 디코딩용 타입입니다 (UnmarshalYAML/TOML 재귀 방지).
+type rawFeed Feed
+
+// UnmarshalYAML 은 enabled 를 생략한 피드를 활성으로 읽습니다.
+func (f *Feed) UnmarshalYAML(n *yaml.Node) error {
+	r := rawFeed{Enabled: true}
+	if err := n.Decode(&r); err != nil {
+		return err
+	}
+	*f = Feed(r)
+	return nil
+}
+
+// UnmarshalTOML 은 enabled 를 생략한 피드를 활성으로 읽습니다.
+func (f *Feed) UnmarshalTOML(data any) error {
+	m, ok := data.(map[string]any)
+	if !ok {
+		return fmt.Errorf("feeds: 테이블이 아님: %T", data)
+	}
+	r := rawFeed{Enabled: true}
+	for k, v := range m {
+		var ok bool
+		switch k {
+		case "name":
+			r.Name, ok = v.(string)
+		case "url":
+			r.URL, ok = v.(string)
+		case "enabled":
+			r.Enabled, ok = v.(bool)
+		case "charset":
+			r.Charset, ok = v.(string)
+		case "full_text":
+			r.FullText, ok = v.(bool)
+		case "interval":
+			var str string
+			if str, ok = v.(string); ok {
+				d, err := time.ParseDuration(str)
+				if err != nil {
+					return fmt.Errorf("feeds.interval: %w", err)
+				}
+				r.Interval = d
+			}
+		default:
+			return fmt.Errorf("feeds: 알 수 없는 키: %s", k)
+		}
+		if !ok {
+			return fmt.Errorf("feeds.%s: 잘못된 타입: %T", k, v)
+		}
+	}
+	*f = Feed(r)
+	return nil
+}
+
+// Redacted 는 비밀 값을 가린 사본을 반환합니다.
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Feeds = slices.Clone(c.Feeds)
+	out.Publish.Queries = slices.Clone(c.Publish.Queries)
+	out.Alerts.Webhooks = slices.Clone(c.Alerts.Webhooks)
+	for i := range out.Alerts.Webhooks {
+		out.Alerts.Webhooks[i].URL = redactURL(out.Alerts.Webhooks[i].URL)
+	}
+	out.Alerts.Rules = slices.Clone(c.Alerts.Rules)
+	out.Digest.To = slices.Clone(c.Digest.To)
+	for _, f := range fields(&out) {
+		if f.Value.Kind() != reflect.String || f.Value.String() == "" {
+			continue
+		}
+		switch f.Secret {
+		case "true":
+			f.Value.SetString("********")
+		case "dsn":
+			f.Value.SetString(redactDSN(f.Value.String()))
+		}
+	}
+	return &out
+}
+
+// redactDSN 은 DSN 의 비밀번호 부분만 가립니다.
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "********"
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+		return strings.Replace(u.String(), ":xxxxx@", ":********@", 1)
+	}
+	return dsn
+}
+
+// redactURL 은 웹훅 URL 의 경로와 쿼리(토큰)를 가리고 스킴과 호스트만 남깁니다.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "********"
+	}
+	return u.Scheme + "://" + u.Host + "/********"
+}
+
+// Print 는 비밀 값을 가린 실제 적용 설정을 YAML 로 출력합니다.
+func Print(w io.Writer, c *Config) error {
+	if c.File != "" {
+		fmt.Fprintf(w, "# 설정 파일: %s\n", c.File)
+	} else {
+		fmt.Fprintln(w, "# 설정 파일: 없음 (기본값)")
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
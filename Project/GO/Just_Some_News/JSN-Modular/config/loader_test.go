@@ -0,0 +1,172 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// load 는 빈 작업 디렉터리에서 args 를 플래그로 읽고 Load 를 호출합니다.
+// 작업 디렉터리의 jsn.yaml 이나 바깥 환경의 systemd 자격 증명을 집어 오지 않도록 격리합니다.
+func load(t *testing.T, args ...string) (*Config, error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv(envCredentialsDir, "")
+	fs := flag.NewFlagSet("jsn", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	l := NewLoader(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return l.Load()
+}
+
+func writeFile(t *testing.T, name, data string, perm os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), perm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoaderLayering(t *testing.T) {
+	files := map[string]string{
+		"jsn.yaml": `
+db:
+  host: file-host
+  name: file-db
+fetch:
+  workers: 8
+  host_min_interval: 5s
+retry:
+  max_delay: 10s
+feeds:
+  - name: boannews
+    url: https://www.boannews.com/media/news_rss.xml
+  - name: off
+    url: https://example.com/rss
+    enabled: false
+`,
+		"jsn.toml": `
+[db]
+host = "file-host"
+name = "file-db"
+
+[fetch]
+workers = 8
+host_min_interval = "5s"
+
+[retry]
+max_delay = "10s"
+
+[[feeds]]
+name = "boannews"
+url = "https://www.boannews.com/media/news_rss.xml"
+
+[[feeds]]
+name = "off"
+url = "https://example.com/rss"
+enabled = false
+`,
+	}
+	for name, data := range files {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, name, data, 0o600)
+			t.Setenv("JSN_FETCH_WORKERS", "6")
+			t.Setenv("JSN_DB_HOST", "env-host")
+			t.Setenv("JSN_RETRY_BASE_DELAY", "2s")
+
+			cfg, err := load(t, "--config", path, "--fetch-workers", "2", "--db-port=3307")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tests := []struct {
+				key       string
+				got, want any
+			}{
+				{"파일 경로", cfg.File, path},
+				{"db.name (파일)", cfg.DB.Name, "file-db"},
+				{"db.host (환경 > 파일)", cfg.DB.Host, "env-host"},
+				{"fetch.workers (플래그 > 환경 > 파일)", cfg.Fetch.Workers, 2},
+				{"db.port (플래그 > 기본)", cfg.DB.Port, 3307},
+				{"fetch.host_min_interval (파일)", cfg.Fetch.HostMinInterval, 5 * time.Second},
+				{"retry.base_delay (환경)", cfg.Retry.BaseDelay, 2 * time.Second},
+				{"retry.max_delay (파일)", cfg.Retry.MaxDelay, 10 * time.Second},
+				{"retry.max_attempts (기본)", cfg.Retry.MaxAttempts, Default().Retry.MaxAttempts},
+				{"피드 수", len(cfg.Feeds), 2},
+				{"enabled 생략 시 활성", cfg.Feeds[0].Enabled, true},
+				{"enabled: false", cfg.Feeds[1].Enabled, false},
+			}
+			for _, tt := range tests {
+				if tt.got != tt.want {
+					t.Errorf("%s = %v, want %v", tt.key, tt.got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderConfigFile(t *testing.T) {
+	// --config 가 없으면 JSN_CONFIG
+	path := writeFile(t, "custom.yml", "db:\n  name: from-env-path\n", 0o600)
+	t.Setenv(envConfigFile, path)
+	cfg, err := load(t)
+	if err != nil || cfg.DB.Name != "from-env-path" || cfg.File != path {
+		t.Fatalf("JSN_CONFIG = %+v, %v", cfg, err)
+	}
+
+	// 둘 다 없고 작업 디렉터리에도 없으면 기본값
+	t.Setenv(envConfigFile, "")
+	cfg, err = load(t)
+	if err != nil || cfg.File != "" || cfg.DB.Name != Default().DB.Name {
+		t.Errorf("설정 파일 없음 = %+v, %v", cfg, err)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string // 파일 이름, 내용은 data
+		data    string
+		env     map[string]string
+		args    []string
+		wantErr []string // 오류를 모두 모아 보고해야 함
+	}{
+		{name: "explicit missing file", args: []string{"--config", "/nonexistent/jsn.yaml"}, wantErr: []string{"설정 파일 읽기 실패"}},
+		{name: "unknown yaml key", file: "jsn.yaml", data: "fetch:\n  wokers: 2\n", wantErr: []string{"wokers"}},
+		{name: "unknown toml key", file: "jsn.toml", data: "[fetch]\nwokers = 2\n", wantErr: []string{"알 수 없는 키: fetch.wokers"}},
+		{name: "unknown extension", file: "jsn.json", data: "{}", wantErr: []string{"확장자로 형식을 알 수 없음"}},
+		{
+			name:    "env and validation errors together",
+			env:     map[string]string{"JSN_FETCH_WORKRS": "2", "JSN_RETRY_MAX_DELAY": "soon", "JSN_FETCH_WORKERS": "0"},
+			wantErr: []string{"JSN_FETCH_WORKRS: 알 수 없는", "JSN_RETRY_MAX_DELAY", "fetch.workers: 1 이상"},
+		},
+		{name: "mysql store", args: []string{"--store", "mysql://u:p@h/db"}, wantErr: []string{"mysql:// 는 지원하지 않음"}},
+		{name: "bad flag", args: []string{"--fetch-workers", "many"}, wantErr: []string{"invalid value"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"--config", writeFile(t, tt.file, tt.data, 0o600)}, args...)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := load(t, args...)
+			if err == nil {
+				t.Fatal("오류 없음")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("오류에 %q 없음: %v", want, err)
+				}
+			}
+		})
+	}
+}
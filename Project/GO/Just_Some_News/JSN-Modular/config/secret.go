@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// envCredentialsDir 는 systemd 가 LoadCredential= 로 넘긴 파일이 있는 디렉터리입니다.
+const envCredentialsDir = "CREDENTIALS_DIRECTORY"
+
+// resolveSecrets 는 secret:"true" 키의 값을 채웁니다. 키 db.password 라면
+//
+//  1. db.password_file (JSN_DB_PASSWORD_FILE, --db-password-file) 이 있으면 그 파일
+//  2. 값이 비어 있으면 $CREDENTIALS_DIRECTORY/db.password (systemd LoadCredential=)
+//  3. 그 외에는 db.password (설정 파일, JSN_DB_PASSWORD, --db-password)
+//
+// 순으로 읽습니다. 값과 파일을 함께 지정하면 오류입니다. 오류에는 값을 넣지 않습니다.
+// 알림 웹훅의 url_file 도 여기서 url 로 읽습니다.
+func resolveSecrets(c *Config) []error {
+	byKey := make(map[string]field)
+	for _, f := range fields(c) {
+		byKey[f.Key] = f
+	}
+
+	var errs []error
+	for _, f := range fields(c) {
+		if f.Secret != "true" {
+			continue
+		}
+		fileField, ok := byKey[f.Key+"_file"]
+		path := ""
+		if ok {
+			path = fileField.Value.String()
+		}
+
+		switch {
+		case path != "" && f.Value.String() != "":
+			errs = append(errs, fmt.Errorf("%s: 값과 %s 를 함께 지정할 수 없음", f.Key, fileField.Key))
+		case path != "":
+			v, err := readSecretFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", fileField.Key, err))
+				continue
+			}
+			f.Value.SetString(v)
+		case f.Value.String() == "" && os.Getenv(envCredentialsDir) != "":
+			path := filepath.Join(os.Getenv(envCredentialsDir), f.Key)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			v, err := readSecretFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: systemd 자격 증명: %w", f.Key, err))
+				continue
+			}
+			f.Value.SetString(v)
+		}
+	}
+
+	// 웹훅 URL 은 목록 항목이라 키가 없으므로 url_file 만 지원
+	for i := range c.Alerts.Webhooks {
+		w := &c.Alerts.Webhooks[i]
+		switch {
+		case w.URLFile != "" && w.URL != "":
+			errs = append(errs, fmt.Errorf("alerts.webhooks[%d]: url 과 url_file 을 함께 지정할 수 없음", i))
+		case w.URLFile != "":
+			v, err := readSecretFile(w.URLFile)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("alerts.webhooks[%d].url_file: %w", i, err))
+				continue
+			}
+			w.URL = v
+		}
+	}
+	return errs
+}
+
+// readSecretFile 은 비밀 파일을 읽고 끝의 줄바꿈을 제거합니다.
+// 다른 사용자가 읽을 수 있는 파일(o+r)은 거부합니다.
+func readSecretFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("비밀 파일 확인 실패: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("비밀 파일이 일반 파일이 아님: %s", path)
+	}
+	if info.Mode().Perm()&0o004 != 0 {
+		return "", fmt.Errorf("다른 사용자가 읽을 수 있는 비밀 파일 (권한 %04o, chmod 600 필요): %s", info.Mode().Perm(), path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("비밀 파일 읽기 실패: %w", err)
+	}
+	v := strings.TrimRight(string(data), "\r\n")
+	if v == "" {
+		return "", fmt.Errorf("비밀 파일이 비어 있음: %s", path)
+	}
+	return v, nil
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	secret := writeFile(t, "db.password", "s3cret\n", 0o600)
+	worldReadable := writeFile(t, "open", "s3cret\n", 0o644)
+	groupReadable := writeFile(t, "group", "s3cret\r\n", 0o640)
+	empty := writeFile(t, "empty", "\n", 0o600)
+
+	tests := []struct {
+		name     string
+		password string
+		file     string
+		want     string
+		wantErr  string
+	}{
+		{name: "file", file: secret, want: "s3cret"},
+		{name: "group readable allowed", file: groupReadable, want: "s3cret"},
+		{name: "plain value", password: "inline", want: "inline"},
+		{name: "world readable rejected", file: worldReadable, wantErr: "chmod 600"},
+		{name: "empty file", file: empty, wantErr: "비어 있음"},
+		{name: "missing file", file: filepath.Join(t.TempDir(), "none"), wantErr: "비밀 파일 확인 실패"},
+		{name: "directory", file: t.TempDir(), wantErr: "일반 파일이 아님"},
+		{name: "value and file", password: "inline", file: secret, wantErr: "함께 지정할 수 없음"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envCredentialsDir, "")
+			c := Default()
+			c.DB.Password, c.DB.PasswordFile = tt.password, tt.file
+			err := errors.Join(resolveSecrets(c)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("오류 = %v, want %q", err, tt.wantErr)
+				}
+				// 오류 메시지에 비밀 값이 들어가면 안 됨
+				if strings.Contains(err.Error(), "s3cret") || strings.Contains(err.Error(), "inline") {
+					t.Errorf("오류에 비밀 값이 있음: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.DB.Password != tt.want {
+				t.Errorf("db.password = %q, want %q", c.DB.Password, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSecretsCredentialsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.password"), []byte("from-systemd\n"), 0o400); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envCredentialsDir, dir)
+
+	c := Default()
+	if errs := resolveSecrets(c); len(errs) > 0 || c.DB.Password != "from-systemd" {
+		t.Errorf("자격 증명 = %q, %v", c.DB.Password, errs)
+	}
+
+	// 값이 있으면 자격 증명보다 우선
+	c = Default()
+	c.DB.Password = "inline"
+	if errs := resolveSecrets(c); len(errs) > 0 || c.DB.Password != "inline" {
+		t.Errorf("값 우선 = %q, %v", c.DB.Password, errs)
+	}
+
+	// 해당 키의 자격 증명 파일이 없으면 건너뜀
+	t.Setenv(envCredentialsDir, t.TempDir())
+	c = Default()
+	if errs := resolveSecrets(c); len(errs) > 0 || c.DB.Password != "" {
+		t.Errorf("자격 증명 없음 = %q, %v", c.DB.Password, errs)
+	}
+
+	// 권한 검사는 자격 증명에도 적용
+	if err := os.Chmod(filepath.Join(dir, "db.password"), 0o604); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envCredentialsDir, dir)
+	c = Default()
+	if err := errors.Join(resolveSecrets(c)...); err == nil || !strings.Contains(err.Error(), "systemd 자격 증명") {
+		t.Errorf("다른 사용자가 읽을 수 있는 자격 증명 오류 = %v", err)
+	}
+}
+
+func TestResolveSecretsWebhookURLFile(t *testing.T) {
+	t.Setenv(envCredentialsDir, "")
+	file := writeFile(t, "hook", "https://hooks.example.com/T000/secret\n", 0o600)
+	c := Default()
+	c.Alerts.Webhooks = []Webhook{
+		{Name: "slack", URLFile: file},
+		{Name: "both", URL: "https://a.example", URLFile: file},
+	}
+	errs := resolveSecrets(c)
+	if c.Alerts.Webhooks[0].URL != "https://hooks.example.com/T000/secret" {
+		t.Errorf("webhooks[0].url = %q", c.Alerts.Webhooks[0].URL)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "alerts.webhooks[1]") {
+		t.Errorf("오류 = %v, want webhooks[1] 하나", errs)
+	}
+}
+
+func TestLoaderPasswordFileFromEnv(t *testing.T) {
+	path := writeFile(t, "pw", "s3cret\n", 0o600)
+	t.Setenv("JSN_DB_PASSWORD_FILE", path)
+	cfg, err := load(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DB.Password != "s3cret" {
+		t.Errorf("db.password = %q", cfg.DB.Password)
+	}
+	if strings.Contains(cfg.Redacted().DSN(), "s3cret") {
+		t.Errorf("Redacted DSN 에 비밀번호가 있음: %s", cfg.Redacted().DSN())
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+
+	"jsn-modular/config"
+)
+
+const configUsage = `사용법: jsn config print
+
+파일, JSN_* 환경 변수, 플래그를 겹친 실제 적용 설정을 YAML 로 출력합니다 (비밀 값은 가림).`
+
+// runConfig 는 jsn config 하위 명령을 실행합니다.
+func runConfig(cfg *config.Config, args []string) int {
+	fs := newFlagSet("config", configUsage)
+	args, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(args) != 1 || args[0] != "print" {
+		return usageError(fs, "하위 명령은 print 만 지원함")
+	}
+	if err := config.Print(os.Stdout, cfg); err != nil {
+		return fail(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/logger"
+	"jsn-modular/internal/rss"
+	"jsn-modular/internal/schedule"
+)
+
+const daemonUsage = `사용법: jsn daemon
+
+프로세스를 유지하며 일정에 따라 수집합니다. 저장소 연결 풀은 하나를 계속 재사용합니다.
+
+  - interval 이 없는 활성 피드는 schedule.cron 일정에 함께 수집 (기본 매시 정각)
+  - interval 이 있는 피드는 그 간격으로 따로 수집
+  - 실행 시각마다 schedule.jitter 안의 무작위 지연을 더함
+  - 수집은 한 번에 하나씩 실행하고, 같은 작업이 아직 실행 중이면 이번 실행은 건너뜀
+  - SIGINT/SIGTERM 을 받으면 새 실행을 멈추고 진행 중인 수집을 schedule.shutdown_timeout 까지 기다림`
+
+// runDaemon 은 jsn daemon 을 실행합니다.
+func runDaemon(cfg *config.Config, args []string) int {
+	fs := newFlagSet("daemon", daemonUsage)
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+	// 설정 검증을 통과했으므로 오류 없음
+	cron, _ := schedule.Parse(cfg.Schedule.Cron)
+
+	logFile := logger.Setup()
+	defer logFile.Close()
+
+	st, err := openStore(cfg)
+	if err != nil {
+		log.Printf("인프라 초기화 실패: %v", err)
+		return exitCode(err)
+	}
+	defer st.Close()
+
+	// runCtx 는 진행 중인 수집에 넘기며, 종료 대기 시간이 지나야 취소
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	defer cancelRun()
+	collect := func(feeds []config.Feed) func() {
+		c := *cfg
+		c.Feeds = feeds
+		return func() {
+			// 데몬은 실패한 피드가 있어도 계속 실행하고 다음 일정에서 다시 시도
+			if run := rss.Collect(runCtx, st, &c); len(run.Failed()) > 0 && !run.Canceled {
+				log.Printf(">>> 피드 %d개 중 %d개 실패:\n%v", len(run.Feeds), len(run.Failed()), run.Err())
+			}
+		}
+	}
+
+	sched := schedule.New(cfg.Schedule.Jitter)
+	var cronFeeds []config.Feed
+	jobs := 0
+	for _, f := range cfg.Feeds {
+		switch {
+		case !f.Enabled:
+		case f.Interval > 0:
+			sched.Add(schedule.Job{
+				Name:      "feed/" + f.Name,
+				Schedule:  schedule.Every(f.Interval),
+				Run:       collect([]config.Feed{f}),
+				Immediate: cfg.Schedule.RunOnStart,
+			})
+			log.Printf(">>> [%s] %s 간격으로 수집", f.Name, f.Interval)
+			jobs++
+		default:
+			cronFeeds = append(cronFeeds, f)
+		}
+	}
+	if len(cronFeeds) > 0 {
+		sched.Add(schedule.Job{Name: "cron", Schedule: cron, Run: collect(cronFeeds), Immediate: cfg.Schedule.RunOnStart})
+		log.Printf(">>> 피드 %d개를 일정 %q 로 수집", len(cronFeeds), cfg.Schedule.Cron)
+		jobs++
+	}
+	if jobs == 0 {
+		log.Println("수집할 활성 피드가 없음")
+		return exitConfig
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	done := make(chan struct{})
+	go func() {
+		sched.Run(ctx)
+		close(done)
+	}()
+	log.Printf(">>> 데몬 시작 (pid %d)", os.Getpid())
+
+	<-ctx.Done()
+	stop() // 두 번째 신호는 기본 동작(즉시 종료)
+	log.Printf(">>> 종료 신호 수신, 진행 중인 수집을 최대 %s 기다림", cfg.Schedule.ShutdownTimeout)
+	select {
+	case <-done:
+	case <-time.After(cfg.Schedule.ShutdownTimeout):
+		log.Println(">>> 대기 시간 초과, 진행 중인 수집 취소")
+		cancelRun()
+		<-done
+	}
+	log.Println(">>> 프로그램 정상 종료.")
+	return exitOK
+}
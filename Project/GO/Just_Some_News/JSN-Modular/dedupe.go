@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+	"jsn-modular/migrate"
+)
+
+const dedupeUsage = `사용법: jsn dedupe [--apply]
+
+canonical_link 마이그레이션에서 다른 기사와 정규화 URL 이 겹쳐 비워 둔 기사를 보여 줍니다.
+--apply 를 주면 트랜잭션 하나 안에서 먼저 저장된 기사만 남기고 나머지를
+검색 색인, 알림 기록과 함께 삭제합니다. 삭제한 기사는 되돌릴 수 없습니다.`
+
+// runDedupe 는 jsn dedupe 를 실행합니다.
+func runDedupe(cfg *config.Config, args []string) int {
+	fs := newFlagSet("dedupe", dedupeUsage)
+	apply := fs.Bool("apply", false, "중복 기사를 실제로 삭제하고 canonical_link 채우기")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+
+	conn, _, err := store.OpenDB(cfg.DSN())
+	if err != nil {
+		return fail(err)
+	}
+	defer conn.Close()
+
+	dups, err := migrate.Dedupe(context.Background(), conn, *apply)
+	if err != nil {
+		return fail(err)
+	}
+	if len(dups) == 0 {
+		fmt.Println("정리할 중복 기사 없음")
+		return exitOK
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tHOLDER\tREMOVE\tCANONICAL\tLINK")
+	removed := 0
+	for _, d := range dups {
+		holder, remove := "-", "-"
+		if d.Holder != 0 {
+			holder, remove = fmt.Sprint(d.Holder), fmt.Sprint(d.Removed)
+			removed++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", d.ID, holder, remove, d.Canonical, d.Link)
+	}
+	w.Flush()
+
+	if !*apply {
+		fmt.Printf("\n대상 %d건 (삭제 예정 %d건). --apply 로 적용\n", len(dups), removed)
+		return exitOK
+	}
+	fmt.Printf("\n대상 %d건 정리, 중복 기사 %d건 삭제\n", len(dups), removed)
+	return exitOK
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/digest"
+	"jsn-modular/internal/store"
+)
+
+const digestUsage = `사용법: jsn digest [--dry-run [--html]] [--to 주소[,주소...]] <daily|weekly>
+
+지난번에 보낸 구간의 끝부터 직전 경계(daily: 오늘 0시, weekly: 이번 주 월요일 0시, 현지 시각)까지
+수집된 신규 기사를 출처별로 묶어 평문/HTML 메일로 보냅니다. 처음에는 직전 하루(한 주)를 보냅니다.
+보낸 구간은 저장소에 기록하므로 같은 구간을 두 번 보내지 않고, 실행을 놓친 구간은 다음 메일에 합칩니다.
+이미 보낸 구간이면 아무것도 하지 않습니다.
+
+--dry-run 은 보내거나 기록하지 않고 제목과 평문 본문(--html 이면 HTML 본문)을 출력합니다.
+--to 로 받는 사람을 바꾸면 시험 전송으로 보고 구간을 기록하지 않습니다.
+SMTP 서버는 digest.smtp 에 둡니다. 로컬 시험 서버(mailpit 등)는 --digest-smtp-tls none 으로 보냅니다.
+
+종료 코드: 0 성공(보낼 구간 없음 포함), 1 메일 전송 실패, 3 저장소 연결 실패, 78 설정 오류`
+
+// runDigest 는 jsn digest 를 실행합니다.
+func runDigest(cfg *config.Config, args []string) int {
+	fs := newFlagSet("digest", digestUsage)
+	dryRun := fs.Bool("dry-run", false, "보내지 않고 제목과 본문을 출력")
+	asHTML := fs.Bool("html", false, "--dry-run: 평문 대신 HTML 본문을 출력")
+	to := fs.String("to", "", "받는 `주소` (쉼표로 구분, 구간을 기록하지 않음)")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) != 1 {
+		return usageError(fs, "주기가 필요함 (daily, weekly)")
+	}
+	kind, err := digest.ParseKind(pos[0])
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	d := cfg.Digest
+	record := true
+	if *to != "" {
+		d.To, record = strings.Split(*to, ","), false
+	}
+	if !*dryRun {
+		switch {
+		case d.SMTP.Host == "":
+			return configError(errors.New("SMTP 서버가 없음 (digest.smtp.host)"))
+		case d.From == "":
+			return configError(errors.New("보내는 주소가 없음 (digest.from)"))
+		case len(d.To) == 0:
+			return configError(errors.New("받는 주소가 없음 (digest.to 또는 --to)"))
+		}
+	}
+	r, err := digest.NewRenderer(d.Subject, d.TextTemplate, d.HTMLTemplate)
+	if err != nil {
+		return configError(err)
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer st.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 1. 보낼 구간: 지난번 구간의 끝 ~ 직전 경계
+	last, err := st.LastDigest(ctx, string(kind))
+	if err != nil {
+		return fail(err)
+	}
+	start, end, ok := digest.Window(kind, last, time.Now())
+	if !ok {
+		log.Printf(">>> %s 다이제스트: 이미 보낸 구간 (%s 까지)", kind, last.Local().Format(time.DateTime))
+		return exitOK
+	}
+
+	// 2. 구간에 수집된 기사를 출처별로 묶어 본문 생성
+	articles, err := st.Query(ctx, store.Query{CollectedSince: start, CollectedUntil: end})
+	if err != nil {
+		return fail(err)
+	}
+	data := digest.Build(kind, start, end, articles, d.MaxPerSource)
+	subject, text, html, err := r.Render(data)
+	if err != nil {
+		return fail(err)
+	}
+	if *dryRun {
+		body := text
+		if *asHTML {
+			body = html
+		}
+		fmt.Printf("Subject: %s\n\n%s", subject, body)
+		return exitOK
+	}
+
+	// 3. 전송 후 구간 기록. 기사가 없으면 (send_empty 가 아니면) 보내지 않고 기록만 함
+	if len(articles) == 0 && !d.SendEmpty {
+		log.Printf(">>> %s 다이제스트: %s 신규 기사 없음, 보내지 않음", kind, data.Period)
+	} else {
+		m := digest.Mail{From: d.From, To: d.To, Subject: subject, Text: text, HTML: html, Date: time.Now()}
+		if err := digest.Send(ctx, d.SMTP, m); err != nil {
+			return fail(fmt.Errorf("%s 다이제스트 전송 실패: %w", kind, err))
+		}
+		log.Printf(">>> %s 다이제스트 발송: %s 기사 %d건 → %s", kind, data.Period, len(articles), strings.Join(d.To, ", "))
+	}
+	if !record {
+		return exitOK
+	}
+	if err := st.SaveDigest(ctx, string(kind), start, end, len(articles)); err != nil {
+		return fail(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/api"
+	"jsn-modular/internal/store"
+)
+
+// exportPageSize 는 내보내기 중 한 번에 읽는 기사 수입니다.
+const exportPageSize = 500
+
+const exportUsage = `사용법: jsn export [--format ndjson|json|csv] [--output 파일] [--source 이름] [--since 날짜] [--until 날짜] [--keyword 검색어]
+
+조건에 맞는 기사를 최신순으로 모두 내보냅니다. --output 이 없으면 표준 출력으로 씁니다.
+csv 는 본문을 빼고 id, source, pub_date, title, link, description_text, cluster_id 컬럼만 씁니다.`
+
+// runExport 는 jsn export 를 실행합니다.
+func runExport(cfg *config.Config, args []string) int {
+	fs := newFlagSet("export", exportUsage)
+	var q store.Query
+	format := fs.String("format", "ndjson", "출력 형식 (ndjson, json, csv)")
+	output := fs.String("output", "", "출력 파일 (기본: 표준 출력)")
+	fs.StringVar(&q.Source, "source", "", "이 출처(피드 이름)의 기사만")
+	fs.StringVar(&q.Keyword, "keyword", "", "제목/설명에 이 검색어가 들어간 기사만")
+	fs.Var(timeValue{&q.Since}, "since", "`날짜` 이후 발행된 기사만")
+	fs.Var(timeValue{&q.Until}, "until", "`날짜` 이전 발행된 기사만 (미포함)")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+	var enc exportEncoder
+	switch *format {
+	case "ndjson":
+		enc = &ndjsonEncoder{}
+	case "json":
+		enc = &jsonArrayEncoder{}
+	case "csv":
+		enc = &csvEncoder{}
+	default:
+		return usageError(fs, "지원하지 않는 형식: %q", *format)
+	}
+
+	st, err := openReadStore(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer st.Close()
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fail(fmt.Errorf("출력 파일 생성 실패: %w", err))
+		}
+		defer f.Close()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+
+	n, err := exportArticles(context.Background(), st, q, enc, bw)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		return fail(fmt.Errorf("내보내기 실패 (%d건 기록 후): %w", n, err))
+	}
+	fmt.Fprintf(os.Stderr, "%d건 내보냄\n", n)
+	return exitOK
+}
+
+// exportArticles 는 (pubDate, id) 커서로 페이지를 넘기며 기사를 모두 씁니다.
+func exportArticles(ctx context.Context, st store.ArticleStore, q store.Query, enc exportEncoder, w io.Writer) (int, error) {
+	q.Limit = exportPageSize
+	n := 0
+	if err := enc.begin(w); err != nil {
+		return 0, err
+	}
+	for {
+		page, err := st.Query(ctx, q)
+		if err != nil {
+			return n, err
+		}
+		for _, a := range page {
+			if err := enc.write(w, api.NewArticle(a)); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(page) < q.Limit {
+			break
+		}
+		last := page[len(page)-1]
+		q.Before = &store.Cursor{PubDate: last.PubDate, ID: last.ID}
+	}
+	return n, enc.end(w)
+}
+
+// exportEncoder 는 내보내기 형식 하나입니다.
+type exportEncoder interface {
+	begin(w io.Writer) error
+	write(w io.Writer, a api.Article) error
+	end(w io.Writer) error
+}
+
+// ndjsonEncoder 는 한 줄에 기사 하나씩 JSON 으로 씁니다.
+type ndjsonEncoder struct{}
+
+func (ndjsonEncoder) begin(io.Writer) error { return nil }
+func (ndjsonEncoder) write(w io.Writer, a api.Article) error {
+	return json.NewEncoder(w).Encode(a)
+}
+func (ndjsonEncoder) end(io.Writer) error { return nil }
+
+// jsonArrayEncoder 는 기사 전체를 JSON 배열 하나로 씁니다.
+type jsonArrayEncoder struct{ n int }
+
+func (e *jsonArrayEncoder) begin(w io.Writer) error {
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (e *jsonArrayEncoder) write(w io.Writer, a api.Article) error {
+	sep := ",\n"
+	if e.n == 0 {
+		sep = "\n"
+	}
+	e.n++
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s", sep, data)
+	return err
+}
+
+func (e *jsonArrayEncoder) end(w io.Writer) error {
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+// csvEncoder 는 본문을 뺀 주요 컬럼을 CSV 로 씁니다.
+type csvEncoder struct{ cw *csv.Writer }
+
+func (e *csvEncoder) begin(w io.Writer) error {
+	e.cw = csv.NewWriter(w)
+	return e.cw.Write([]string{"id", "source", "pub_date", "title", "link", "description_text", "cluster_id"})
+}
+
+func (e *csvEncoder) write(_ io.Writer, a api.Article) error {
+	return e.cw.Write([]string{
+		strconv.FormatInt(a.ID, 10), a.Source, a.PubDate.Format(time.RFC3339), a.Title, a.Link,
+		a.DescriptionText, strconv.FormatInt(a.ClusterID, 10),
+	})
+}
+
+func (e *csvEncoder) end(io.Writer) error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
@@ -0,0 +1,144 @@
+// Package feedcharset decodes feed documents in any WHATWG-labelled charset to UTF-8.
+// JSN-Monolithic 도 같은 인코딩 판별을 쓰도록 internal 밖에 둡니다.
+package feedcharset
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// xmlDeclEncoding 은 XML 프롤로그의 encoding 선언을 찾습니다.
+var xmlDeclEncoding = regexp.MustCompile(`^\s*<\?xml[^>]*?\sencoding\s*=\s*["']([^"']+)["']`)
+
+// sniffCandidates 는 선언이 없고 UTF-8 도 아닌 본문에 차례로 시도하는 인코딩입니다.
+// 국내 피드를 우선하며, windows-1252 는 모든 바이트를 해석하므로 마지막에 둡니다.
+var sniffCandidates = []string{"euc-kr", "shift_jis", "windows-1252"}
+
+// labelAliases 는 WHATWG 표에는 없지만 피드에서 자주 보이는 레이블의 별칭입니다.
+var labelAliases = map[string]string{
+	"cp949": "euc-kr",
+	"ms949": "euc-kr",
+	"uhc":   "euc-kr",
+	"cp932": "shift_jis",
+	"ms932": "shift_jis",
+	"utf8":  "utf-8",
+}
+
+// lookupEncoding 은 WHATWG 인코딩 레이블(ks_c_5601-1987, latin1, sjis 등)로 인코딩을 찾습니다.
+func lookupEncoding(label string) (encoding.Encoding, error) {
+	if alias, ok := labelAliases[strings.ToLower(strings.TrimSpace(label))]; ok {
+		label = alias
+	}
+	enc, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset: %s", label)
+	}
+	return enc, nil
+}
+
+// NewReader 는 charset 레이블에 맞는 UTF-8 변환 리더를 반환합니다.
+func NewReader(label string, input io.Reader) (io.Reader, error) {
+	enc, err := lookupEncoding(label)
+	if err != nil {
+		return nil, err
+	}
+	if enc == unicode.UTF8 {
+		return input, nil
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// bomCharset 은 BOM 으로 인코딩을 판별하고 BOM 을 제거한 본문을 반환합니다.
+func bomCharset(data []byte) (string, []byte) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return "utf-8", data[3:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return "utf-16le", data[2:]
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return "utf-16be", data[2:]
+	}
+	return "", data
+}
+
+// contentTypeCharset 은 HTTP Content-Type 헤더의 charset 파라미터를 반환합니다.
+func contentTypeCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return params["charset"]
+}
+
+// sniffCharset 은 선언이 없는 본문의 인코딩을 바이트 패턴으로 추정합니다.
+// 유효한 UTF-8 이면 utf-8, 아니면 대체 문자(U+FFFD) 없이 해석되는 첫 후보를 고릅니다.
+func sniffCharset(data []byte) string {
+	if utf8.Valid(data) {
+		return "utf-8"
+	}
+	for _, label := range sniffCandidates {
+		enc, err := lookupEncoding(label)
+		if err != nil {
+			continue
+		}
+		out, err := enc.NewDecoder().Bytes(data)
+		if err == nil && !bytes.ContainsRune(out, utf8.RuneError) {
+			return label
+		}
+	}
+	return sniffCandidates[len(sniffCandidates)-1]
+}
+
+// Resolve 는 XML 디코더에 넘기기 전에 미리 변환할 인코딩을 결정합니다.
+// 우선순위는 피드 설정 > BOM > XML 선언 > HTTP Content-Type > 바이트 스니핑입니다.
+// XML 선언이 있으면 빈 레이블을 반환해 encoding/xml 의 CharsetReader 에 맡깁니다.
+func Resolve(data []byte, contentType, feedCharset string) (label string, body []byte) {
+	if feedCharset != "" {
+		return feedCharset, data
+	}
+	if label, body := bomCharset(data); label != "" {
+		return label, body
+	}
+	if xmlDeclEncoding.Match(data) {
+		return "", data
+	}
+	if label := contentTypeCharset(contentType); label != "" {
+		return label, data
+	}
+	return sniffCharset(data), data
+}
+
+// NewDecoder 는 charset 처리가 연결된 XML 디코더를 만듭니다. charset 은 피드 설정의 강제 인코딩입니다.
+// XML 선언이 없으면 Resolve 가 정한 인코딩으로 본문을 미리 UTF-8 로 변환합니다.
+func NewDecoder(data []byte, contentType, charset string) (*xml.Decoder, error) {
+	label, data := Resolve(data, contentType, charset)
+
+	var body io.Reader = bytes.NewReader(data)
+	if label != "" {
+		r, err := NewReader(label, body)
+		if err != nil {
+			return nil, err
+		}
+		body = r
+	}
+
+	decoder := xml.NewDecoder(body)
+	decoder.CharsetReader = func(declared string, input io.Reader) (io.Reader, error) {
+		if label != "" {
+			// 이미 UTF-8 로 변환된 입력 (피드 설정 또는 BOM 이 XML 선언보다 우선)
+			return input, nil
+		}
+		return NewReader(declared, input)
+	}
+	return decoder, nil
+}
@@ -0,0 +1,124 @@
+package feedcharset
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func eucKR(t *testing.T, s string) string {
+	t.Helper()
+	b, err := korean.EUCKR.NewEncoder().String(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSniffCharset(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"ascii", "<rss/>", "utf-8"},
+		{"utf-8", "<title>보안뉴스</title>", "utf-8"},
+		{"euc-kr", "<title>" + eucKR(t, "랜섬웨어 조직 검거") + "</title>", "euc-kr"},
+		{"latin1", "<title>caf\xe9 au lait</title>", "windows-1252"},
+	}
+	for _, tt := range tests {
+		if got := sniffCharset([]byte(tt.data)); got != tt.want {
+			t.Errorf("%s: sniffCharset = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	const decl = `<?xml version="1.0" encoding="EUC-KR"?><rss/>`
+	euc := eucKR(t, "<rss><title>보안</title></rss>")
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		feedCharset string
+		wantLabel   string
+		wantBody    string
+	}{
+		{"feed setting wins", "\xef\xbb\xbf" + decl, "text/xml; charset=utf-8", "cp949", "cp949", "\xef\xbb\xbf" + decl},
+		{"utf-8 BOM stripped", "\xef\xbb\xbf<rss/>", "text/xml; charset=euc-kr", "", "utf-8", "<rss/>"},
+		{"utf-16le BOM", "\xff\xfe<\x00", "", "", "utf-16le", "<\x00"},
+		{"utf-16be BOM", "\xfe\xff\x00<", "", "", "utf-16be", "\x00<"},
+		// XML 선언과 HTTP 헤더가 다르면 선언을 따름 (CharsetReader 에 맡김)
+		{"declaration over header", decl, "application/rss+xml; charset=UTF-8", "", "", decl},
+		{"header charset", "<rss/>", `text/xml; charset="ks_c_5601-1987"`, "", "ks_c_5601-1987", "<rss/>"},
+		{"broken header sniffs", euc, "text/xml; charset", "", "euc-kr", euc},
+		{"sniffed", euc, "application/xml", "", "euc-kr", euc},
+	}
+	for _, tt := range tests {
+		label, body := Resolve([]byte(tt.data), tt.contentType, tt.feedCharset)
+		if label != tt.wantLabel || string(body) != tt.wantBody {
+			t.Errorf("%s: Resolve = %q, %q, want %q, %q", tt.name, label, body, tt.wantLabel, tt.wantBody)
+		}
+	}
+}
+
+func TestNewDecoder(t *testing.T) {
+	utf16, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(
+		`<?xml version="1.0" encoding="utf-16"?><rss><title>보안뉴스</title></rss>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		charset     string
+	}{
+		{"declared euc-kr", eucKR(t, `<?xml version="1.0" encoding="euc-kr"?><rss><title>보안뉴스</title></rss>`), "text/xml; charset=utf-8", ""},
+		{"header euc-kr", eucKR(t, `<rss><title>보안뉴스</title></rss>`), "text/xml; charset=EUC-KR", ""},
+		{"sniffed euc-kr", eucKR(t, `<rss><title>보안뉴스</title></rss>`), "", ""},
+		// 피드 설정이 잘못된 XML 선언보다 우선
+		{"forced cp949", eucKR(t, `<?xml version="1.0" encoding="utf-8"?><rss><title>보안뉴스</title></rss>`), "", "cp949"},
+		{"utf-16 BOM", utf16, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDecoder([]byte(tt.data), tt.contentType, tt.charset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var doc struct {
+				Title string `xml:"title"`
+			}
+			if err := d.Decode(&doc); err != nil {
+				t.Fatal(err)
+			}
+			if doc.Title != "보안뉴스" {
+				t.Errorf("제목 = %q", doc.Title)
+			}
+		})
+	}
+
+	if _, err := NewDecoder([]byte("<rss/>"), "", "klingon"); err == nil {
+		t.Error("알 수 없는 charset 에 오류가 없음")
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	for _, label := range []string{"euc-kr", "CP949", " ms949 ", "ks_c_5601-1987", "windows-949"} {
+		r, err := NewReader(label, strings.NewReader(eucKR(t, "보안")))
+		if err != nil {
+			t.Errorf("NewReader(%q): %v", label, err)
+			continue
+		}
+		if b, _ := io.ReadAll(r); string(b) != "보안" {
+			t.Errorf("NewReader(%q) = %q", label, b)
+		}
+	}
+	if _, err := NewReader("utf-7", strings.NewReader("")); err == nil {
+		t.Error("utf-7 에 오류가 없음")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+const feedsUsage = `사용법:
+  jsn feeds list [--json]
+  jsn feeds add [--charset 이름] [--full-text] [--interval 간격] [--disabled] <이름> <URL>
+  jsn feeds remove <이름>
+
+list 는 설정의 피드와 저장소의 피드 상태(연속 실패 횟수)를 출력합니다.
+add, remove 는 YAML 설정 파일의 feeds 목록을 고칩니다 (주석은 유지). 수집된 기사는 지우지 않습니다.`
+
+// runFeeds 는 jsn feeds 하위 명령을 실행합니다.
+func runFeeds(cfg *config.Config, args []string) int {
+	fs := newFlagSet("feeds", feedsUsage)
+	asJSON := fs.Bool("json", false, "list: JSON 으로 출력")
+	charset := fs.String("charset", "", "add: 피드 문자 집합 (예: euc-kr, 비우면 XML 선언을 따름)")
+	fullText := fs.Bool("full-text", false, "add: 신규 기사의 원문 본문을 추출")
+	disabled := fs.Bool("disabled", false, "add: 비활성 상태로 추가")
+	interval := fs.Duration("interval", 0, "add: jsn daemon 에서 schedule.cron 대신 쓸 수집 `간격` (예: 30m)")
+	args, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(args) == 0 {
+		return usageError(fs, "하위 명령이 필요함")
+	}
+
+	switch args[0] {
+	case "list":
+		if len(args) != 1 {
+			return usageError(fs, "알 수 없는 인자: %q", args[1])
+		}
+		return listFeeds(cfg, *asJSON)
+	case "add":
+		if len(args) != 3 {
+			return usageError(fs, "이름과 URL 이 필요함")
+		}
+		f := config.Feed{Name: args[1], URL: args[2], Enabled: !*disabled, Charset: *charset, FullText: *fullText, Interval: *interval}
+		if err := config.AddFeed(cfg, f); err != nil {
+			return fail(fmt.Errorf("피드 추가 실패: %w", err))
+		}
+		fmt.Printf("피드 추가: %s (%s) → %s\n", f.Name, f.URL, cfg.File)
+		return exitOK
+	case "remove":
+		if len(args) != 2 {
+			return usageError(fs, "이름이 필요함")
+		}
+		if err := config.RemoveFeed(cfg, args[1]); err != nil {
+			return fail(fmt.Errorf("피드 삭제 실패: %w", err))
+		}
+		fmt.Printf("피드 삭제: %s → %s (수집된 기사는 jsn prune 로 정리)\n", args[1], cfg.File)
+		return exitOK
+	}
+	return usageError(fs, "알 수 없는 하위 명령: %q", args[0])
+}
+
+// feedRow 는 jsn feeds list 의 한 줄입니다.
+type feedRow struct {
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Enabled     bool      `json:"enabled"`
+	FullText    bool      `json:"full_text"`
+	Failures    int       `json:"consecutive_failures"`
+	LastFailure time.Time `json:"last_failure_at,omitzero"`
+}
+
+func listFeeds(cfg *config.Config, asJSON bool) int {
+	// 저장소에 연결하지 못해도 설정의 피드 목록은 보여 줌
+	st, err := openReadStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "피드 상태 없이 출력: %v\n", err)
+	}
+
+	ctx := context.Background()
+	rows := make([]feedRow, 0, len(cfg.Feeds))
+	for _, f := range cfg.Feeds {
+		row := feedRow{Name: f.Name, URL: f.URL, Enabled: f.Enabled, FullText: f.FullText}
+		if st != nil {
+			var state store.FeedState
+			if state, err = st.LoadFeedState(ctx, f.Name, f.URL); err != nil {
+				st.Close()
+				return fail(fmt.Errorf("피드 상태 조회 실패: %w", err))
+			}
+			row.Failures, row.LastFailure = state.Failures, state.LastFailure
+		}
+		rows = append(rows, row)
+	}
+	if st != nil {
+		st.Close()
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fail(err)
+		}
+		return exitOK
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tFAILURES\tLAST FAILURE\tURL")
+	for _, r := range rows {
+		status := "enabled"
+		if !r.Enabled {
+			status = "disabled"
+		}
+		if r.FullText {
+			status += ",full-text"
+		}
+		last := "-"
+		if !r.LastFailure.IsZero() {
+			last = r.LastFailure.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", r.Name, status, r.Failures, last, r.URL)
+	}
+	w.Flush()
+	return exitOK
+}
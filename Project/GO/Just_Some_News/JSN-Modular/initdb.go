@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+	"jsn-modular/migrate"
+)
+
+const initDBUsage = `사용법: jsn init-db
+
+데이터베이스가 없으면 만들고 (MariaDB) 적용하지 않은 스키마 마이그레이션을 모두 적용합니다.
+여러 번 실행해도 안전합니다. 마이그레이션을 하나씩 다루려면 jsn migrate 를 씁니다.`
+
+// runInitDB 는 jsn init-db 를 실행합니다.
+func runInitDB(cfg *config.Config, args []string) int {
+	fs := newFlagSet("init-db", initDBUsage)
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+
+	conn, dialect, err := store.OpenDB(cfg.DSN())
+	if err != nil {
+		return fail(err)
+	}
+	defer conn.Close()
+	m, err := migrate.New(conn, dialect)
+	if err != nil {
+		return fail(err)
+	}
+
+	applied, err := m.Up(context.Background())
+	for _, mig := range applied {
+		fmt.Printf("적용: %s\n", mig)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Printf("%s 스키마 준비 완료 (마이그레이션 %d개 적용)\n", dialect, len(applied))
+	return exitOK
+}
@@ -0,0 +1,230 @@
+// Package alert evaluates watch rules against newly collected articles and delivers matches to webhooks.
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/severity"
+	"jsn-modular/internal/store"
+)
+
+// retryWindow 는 failed 알림을 다음 수집에서 다시 보내는 기간입니다. 이보다 오래된 알림은 포기합니다.
+const retryWindow = 24 * time.Hour
+
+// Notifier 는 관심 키워드 규칙과 웹훅입니다.
+type Notifier struct {
+	rules       []rule
+	hooks       []webhook
+	client      *http.Client
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+type webhook struct {
+	name    string
+	url     string
+	payload payload
+}
+
+// Match 는 기사 하나를 웹훅 하나로 보낼 알림입니다. 한 웹훅에 여러 규칙이 맞아도 한 번만 보냅니다.
+type Match struct {
+	Webhook string
+	Message Message
+}
+
+// Result 는 Notify 한 번의 처리 건수입니다.
+type Result struct {
+	Sent    int // 전송 성공 (재전송 포함)
+	Failed  int // 재시도 후에도 실패, 다음 수집에서 다시 보냄
+	Skipped int // 이미 보낸 알림
+}
+
+// New 는 설정의 규칙과 웹훅 템플릿을 컴파일합니다. 규칙이나 웹훅이 없으면 Enabled 가 false 입니다.
+func New(cfg config.Alerts) (*Notifier, error) {
+	n := &Notifier{
+		client:      &http.Client{Timeout: cfg.Timeout},
+		maxAttempts: cfg.MaxAttempts,
+		retryDelay:  cfg.RetryDelay,
+	}
+	var names []string
+	for _, w := range cfg.Webhooks {
+		p, err := newPayload(w.Name, w.Format, w.Template)
+		if err != nil {
+			return nil, err
+		}
+		n.hooks = append(n.hooks, webhook{name: w.Name, url: w.URL, payload: p})
+		names = append(names, w.Name)
+	}
+	for _, r := range cfg.Rules {
+		cr, err := compileRule(r, names)
+		if err != nil {
+			return nil, fmt.Errorf("알림 규칙 %s: %w", r.Name, err)
+		}
+		n.rules = append(n.rules, cr)
+	}
+	return n, nil
+}
+
+// Enabled 는 보낼 규칙과 웹훅이 있는지 확인합니다.
+func (n *Notifier) Enabled() bool { return len(n.rules) > 0 && len(n.hooks) > 0 }
+
+// Webhooks 는 설정 순서대로의 웹훅 이름입니다.
+func (n *Notifier) Webhooks() []string {
+	names := make([]string, len(n.hooks))
+	for i, h := range n.hooks {
+		names[i] = h.name
+	}
+	return names
+}
+
+// Match 는 기사에 규칙을 적용해 웹훅별 알림을 설정 순서대로 반환합니다.
+func (n *Notifier) Match(a store.Article) []Match {
+	level := severity.Classify(a.Title, a.DescriptionText)
+	byHook := make(map[string]*Message)
+	for _, r := range n.rules {
+		found, ok := r.match(a, level)
+		if !ok {
+			continue
+		}
+		for _, h := range r.webhooks {
+			m := byHook[h]
+			if m == nil {
+				msg := newMessage(a, level)
+				m = &msg
+				byHook[h] = m
+			}
+			m.Rules = append(m.Rules, r.name)
+			for _, f := range found {
+				if !slices.Contains(m.Matches, f) {
+					m.Matches = append(m.Matches, f)
+				}
+			}
+		}
+	}
+
+	var out []Match
+	for _, h := range n.hooks {
+		if m, ok := byHook[h.name]; ok {
+			out = append(out, Match{Webhook: h.name, Message: *m})
+		}
+	}
+	return out
+}
+
+func newMessage(a store.Article, level severity.Level) Message {
+	return Message{
+		Rules:     []string{},
+		Matches:   []string{},
+		Severity:  level.String(),
+		ArticleID: a.ID,
+		Source:    a.Source,
+		Title:     a.Title,
+		Link:      a.Link,
+		PubDate:   a.PubDate,
+		Summary:   truncate(summaryLen, strings.Join(strings.Fields(a.DescriptionText), " ")),
+	}
+}
+
+// Send 는 알림 하나를 웹훅으로 보내고 시도 횟수를 반환합니다. 저장소에 기록하지 않습니다.
+func (n *Notifier) Send(ctx context.Context, hook string, m Message) (int, error) {
+	i := slices.IndexFunc(n.hooks, func(h webhook) bool { return h.name == hook })
+	if i < 0 {
+		return 0, fmt.Errorf("설정에 없는 웹훅: %q", hook)
+	}
+	body, err := n.hooks[i].payload.render(m)
+	if err != nil {
+		return 0, err
+	}
+	return n.post(ctx, n.hooks[i].url, body)
+}
+
+// Notify 는 이전 실행에서 실패한 알림을 다시 보낸 뒤 신규 기사의 알림을 보냅니다.
+// (기사, 웹훅) 마다 전송 전에 기록을 선점하므로 같은 기사를 두 번 알리지 않습니다.
+func (n *Notifier) Notify(ctx context.Context, st store.Store, articles []store.Article) Result {
+	var res Result
+	n.retryFailed(ctx, st, &res)
+
+	for _, a := range articles {
+		for _, m := range n.Match(a) {
+			if ctx.Err() != nil {
+				return res
+			}
+			ok, err := st.ClaimAlert(ctx, a.ID, m.Webhook, m.Message.Rules)
+			if err != nil {
+				// 기록하지 못하면 중복 방지를 보장할 수 없으므로 보내지 않음
+				log.Printf(">>> [%s] 알림 기록 실패, 보내지 않음: %s: %v", m.Webhook, a.Title, err)
+				res.Failed++
+				continue
+			}
+			if !ok {
+				res.Skipped++
+				continue
+			}
+			n.deliver(ctx, st, m, &res)
+		}
+	}
+	return res
+}
+
+// retryFailed 는 retryWindow 안의 failed 알림을 다시 보냅니다. 설정에서 빠진 웹훅은 건너뜁니다.
+func (n *Notifier) retryFailed(ctx context.Context, st store.Store, res *Result) {
+	failed, err := st.FailedAlerts(ctx, time.Now().Add(-retryWindow))
+	if err != nil {
+		log.Printf(">>> 실패한 알림 조회 실패: %v", err)
+		return
+	}
+	for _, d := range failed {
+		if ctx.Err() != nil {
+			return
+		}
+		if !slices.ContainsFunc(n.hooks, func(h webhook) bool { return h.name == d.Webhook }) {
+			continue
+		}
+		a, err := st.Get(ctx, d.ArticleID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			log.Printf(">>> [%s] 알림 재전송 실패: 기사 #%d 조회 실패: %v", d.Webhook, d.ArticleID, err)
+			continue
+		}
+		if ok, err := st.ReclaimAlert(ctx, d.ArticleID, d.Webhook); err != nil || !ok {
+			continue
+		}
+
+		// 규칙은 처음 기록한 것을 쓰고, 일치 부분은 현재 규칙으로 다시 찾음
+		m := Match{Webhook: d.Webhook, Message: newMessage(a, severity.Classify(a.Title, a.DescriptionText))}
+		m.Message.Rules = d.Rules
+		for _, cur := range n.Match(a) {
+			if cur.Webhook == d.Webhook {
+				m.Message.Matches = cur.Message.Matches
+			}
+		}
+		log.Printf(">>> [%s] 알림 재전송 (이전 시도 %d회): %s", d.Webhook, d.Attempts, a.Title)
+		n.deliver(ctx, st, m, res)
+	}
+}
+
+// deliver 는 선점한 알림을 보내고 결과를 기록합니다.
+func (n *Notifier) deliver(ctx context.Context, st store.Store, m Match, res *Result) {
+	attempts, err := n.Send(ctx, m.Webhook, m.Message)
+	if err != nil {
+		res.Failed++
+		log.Printf(">>> [%s] 알림 발송 실패 (%d회 시도): %s: %v", m.Webhook, attempts, m.Message.Title, err)
+	} else {
+		res.Sent++
+		log.Printf(">>> [%s] 알림 발송: [%s] %s (규칙: %s)", m.Webhook, m.Message.Severity, m.Message.Title, strings.Join(m.Message.Rules, ", "))
+	}
+	// 종료 신호로 ctx 가 취소되어도 결과는 기록해야 다음 실행에서 재시도하거나 건너뜀
+	if err := st.FinishAlert(context.WithoutCancel(ctx), m.Message.ArticleID, m.Webhook, attempts, err); err != nil {
+		log.Printf(">>> [%s] 알림 기록 실패: %v", m.Webhook, err)
+	}
+}
@@ -0,0 +1,139 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"jsn-modular/internal/severity"
+)
+
+// summaryLen 은 알림 본문에 넣는 설명의 최대 글자 수입니다.
+const summaryLen = 300
+
+// Message 는 웹훅 본문 템플릿에 넘기는 알림 내용입니다. json 형식은 이 구조체를 그대로 보냅니다.
+type Message struct {
+	Rules     []string  `json:"rules"`
+	Matches   []string  `json:"matches"` // 일치한 키워드와 정규식 부분
+	Severity  string    `json:"severity"`
+	ArticleID int64     `json:"article_id"`
+	Source    string    `json:"source"`
+	Title     string    `json:"title"`
+	Link      string    `json:"link"`
+	PubDate   time.Time `json:"pub_date"`
+	Summary   string    `json:"summary"`
+	Test      bool      `json:"test,omitempty"` // jsn alerts test 의 시험 전송
+}
+
+// funcs 는 웹훅 템플릿에서 쓸 수 있는 함수입니다. 문자열을 JSON 에 넣을 때는 반드시 json 을 씁니다.
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join":     strings.Join,
+	"upper":    strings.ToUpper,
+	"truncate": truncate,
+	// slack 은 Slack mrkdwn 의 제어 문자(&, <, >)를 이스케이프합니다.
+	"slack": strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace,
+	// color 는 심각도별 Discord embed 색(정수)입니다.
+	"color": func(s string) int {
+		l, _ := severity.Parse(s)
+		return [...]int{0x95a5a6, 0xf1c40f, 0xe67e22, 0xe74c3c}[l]
+	},
+}
+
+// builtin 은 형식별 내장 본문 템플릿입니다. json 은 Message 를 직렬화하므로 템플릿이 없습니다.
+var builtin = map[string]string{
+	"slack": `{
+  "text": {{json (printf "[%s] %s" (upper .Severity) .Title)}},
+  "blocks": [
+    {"type": "section", "text": {"type": "mrkdwn", "text": {{json (printf "*[%s]* <%s|%s>\n%s" (upper .Severity) .Link (slack .Title) (slack .Summary))}}}},
+    {"type": "context", "elements": [{"type": "mrkdwn", "text": {{json (printf "출처: %s · 규칙: %s · 일치: %s" .Source (join .Rules ", ") (join .Matches ", "))}}}]}
+  ]
+}`,
+	"discord": `{
+  "username": "jsn",
+  "embeds": [{
+    "title": {{json (truncate 256 (printf "[%s] %s" (upper .Severity) .Title))}},
+    "url": {{json .Link}},
+    {{- if .Summary}}
+    "description": {{json .Summary}},
+    {{- end}}
+    "color": {{color .Severity}},
+    "timestamp": {{json .PubDate}},
+    "fields": [
+      {"name": "출처", "value": {{json .Source}}, "inline": true},
+      {"name": "규칙", "value": {{json (truncate 1024 (join .Rules ", "))}}, "inline": true}
+    ]
+  }]
+}`,
+	"teams": `{
+  "type": "message",
+  "attachments": [{
+    "contentType": "application/vnd.microsoft.card.adaptive",
+    "content": {
+      "$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+      "type": "AdaptiveCard",
+      "version": "1.4",
+      "body": [
+        {"type": "TextBlock", "text": {{json (printf "[%s] %s" (upper .Severity) .Title)}}, "weight": "Bolder", "size": "Medium", "wrap": true},
+        {"type": "TextBlock", "text": {{json .Summary}}, "wrap": true},
+        {"type": "FactSet", "facts": [
+          {"title": "출처", "value": {{json .Source}}},
+          {"title": "규칙", "value": {{json (join .Rules ", ")}}},
+          {"title": "일치", "value": {{json (join .Matches ", ")}}},
+          {"title": "발행", "value": {{json (.PubDate.Local.Format "2006-01-02 15:04")}}}
+        ]}
+      ],
+      "actions": [{"type": "Action.OpenUrl", "title": "기사 열기", "url": {{json .Link}}}]
+    }
+  }]
+}`,
+}
+
+// payload 는 웹훅 하나의 본문 생성기입니다. tmpl 이 nil 이면 Message 를 JSON 으로 보냅니다.
+type payload struct {
+	tmpl *template.Template
+}
+
+func newPayload(name, format, text string) (payload, error) {
+	if text == "" {
+		text = builtin[format]
+	}
+	if text == "" {
+		return payload{}, nil
+	}
+	t, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return payload{}, fmt.Errorf("웹훅 %s 템플릿 오류: %w", name, err)
+	}
+	return payload{tmpl: t}, nil
+}
+
+// render 는 알림 본문을 만듭니다. 템플릿 결과가 올바른 JSON 이 아니면 오류입니다.
+func (p payload) render(m Message) ([]byte, error) {
+	if p.tmpl == nil {
+		return json.Marshal(m)
+	}
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, m); err != nil {
+		return nil, fmt.Errorf("웹훅 본문 생성 실패: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("웹훅 본문이 올바른 JSON 이 아님 (문자열은 {{json .Title}} 처럼 json 함수로 넣으세요)")
+	}
+	return buf.Bytes(), nil
+}
+
+// truncate 는 s 를 n 글자로 자르고 잘렸으면 "…" 를 붙입니다.
+func truncate(n int, s string) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
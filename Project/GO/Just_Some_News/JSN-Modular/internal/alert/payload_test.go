@@ -0,0 +1,82 @@
+package alert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuiltinTemplatesEscape(t *testing.T) {
+	m := Message{
+		Rules:     []string{"vpn \"긴급\""},
+		Matches:   []string{"<VPN>", "a|b"},
+		Severity:  "critical",
+		ArticleID: 7,
+		Source:    "boannews",
+		Title:     "\"긴급\" <VPN> 취약점 | 패치\n두 번째 줄\t탭 \\ 역슬래시",
+		Link:      "https://www.boannews.com/media/view.asp?idx=1&kind=1",
+		PubDate:   time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		Summary:   "요약 \"인용\" & <태그>\n줄바꿈",
+	}
+	for format := range builtin {
+		t.Run(format, func(t *testing.T) {
+			p, err := newPayload("test", format, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := p.render(m)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			var v any
+			if err := json.Unmarshal(body, &v); err != nil {
+				t.Fatalf("올바른 JSON 이 아님: %v\n%s", err, body)
+			}
+			// 제목은 (Slack 이스케이프를 거쳐도) 문자열 값 안에 그대로 남아야 함
+			if !strings.Contains(string(body), `\"긴급\"`) || !strings.Contains(string(body), `\n두 번째 줄`) {
+				t.Errorf("제목이 JSON 문자열로 들어가지 않음:\n%s", body)
+			}
+		})
+	}
+}
+
+func TestSlackTemplateEscapesMarkup(t *testing.T) {
+	p, err := newPayload("test", "slack", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := p.render(Message{Severity: "high", Title: "<script> & co", Link: "https://example.com/a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v struct {
+		Blocks []struct {
+			Text struct{ Text string } `json:"text"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Blocks[0].Text.Text; !strings.Contains(got, "|&lt;script&gt; &amp; co>") {
+		t.Errorf("mrkdwn = %q, want 이스케이프된 제목", got)
+	}
+}
+
+func TestCustomTemplateMustBeJSON(t *testing.T) {
+	p, err := newPayload("test", "json", `{"text": "{{.Title}}"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.render(Message{Title: `따옴표 " 포함`}); err == nil {
+		t.Error("json 함수 없이 넣은 따옴표가 통과함")
+	}
+	p, err = newPayload("test", "json", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := p.render(Message{Title: "t", Rules: []string{"r"}})
+	if err != nil || !json.Valid(body) {
+		t.Errorf("기본 json 본문 = %s, %v", body, err)
+	}
+}
@@ -0,0 +1,76 @@
+package alert
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/severity"
+	"jsn-modular/internal/store"
+)
+
+// rule 은 컴파일한 관심 키워드 규칙입니다.
+type rule struct {
+	name     string
+	keywords []string // 소문자
+	raw      []string // 알림에 보일 원래 키워드
+	regexes  []*regexp.Regexp
+	sources  []string
+	min      severity.Level
+	webhooks []string
+}
+
+func compileRule(r config.WatchRule, hooks []string) (rule, error) {
+	out := rule{name: r.Name, sources: r.Sources, webhooks: r.Webhooks, raw: r.Keywords}
+	if len(out.webhooks) == 0 {
+		out.webhooks = hooks
+	}
+	for _, k := range r.Keywords {
+		out.keywords = append(out.keywords, strings.ToLower(strings.TrimSpace(k)))
+	}
+	for _, s := range r.Regexes {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return rule{}, err
+		}
+		out.regexes = append(out.regexes, re)
+	}
+	if r.MinSeverity != "" {
+		l, err := severity.Parse(r.MinSeverity)
+		if err != nil {
+			return rule{}, err
+		}
+		out.min = l
+	}
+	return out, nil
+}
+
+// match 는 기사가 규칙에 맞는지와, 일치한 키워드와 정규식 부분을 반환합니다.
+// 키워드와 정규식이 모두 없는 규칙은 출처와 심각도 조건만 봅니다.
+func (r rule) match(a store.Article, level severity.Level) ([]string, bool) {
+	if len(r.sources) > 0 && !slices.Contains(r.sources, a.Source) {
+		return nil, false
+	}
+	if level < r.min {
+		return nil, false
+	}
+	if len(r.keywords) == 0 && len(r.regexes) == 0 {
+		return nil, true
+	}
+
+	text := a.Title + "\n" + a.DescriptionText
+	lower := strings.ToLower(text)
+	var found []string
+	for i, k := range r.keywords {
+		if strings.Contains(lower, k) {
+			found = append(found, strings.TrimSpace(r.raw[i]))
+		}
+	}
+	for _, re := range r.regexes {
+		if loc := re.FindStringIndex(text); loc != nil {
+			found = append(found, text[loc[0]:loc[1]])
+		}
+	}
+	return found, len(found) > 0
+}
@@ -0,0 +1,64 @@
+package alert
+
+import (
+	"slices"
+	"testing"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/severity"
+	"jsn-modular/internal/store"
+)
+
+func TestRuleMatch(t *testing.T) {
+	article := store.Article{
+		Source:          "boannews",
+		Title:           "Ivanti VPN 원격 코드 실행 취약점 CVE-2026-1234 패치",
+		DescriptionText: "관리 페이지의 입력값 검증 오류로 Ransomware 조직이 악용 중이다.",
+	}
+	tests := []struct {
+		name  string
+		rule  config.WatchRule
+		level severity.Level
+		want  []string
+		ok    bool
+	}{
+		{"keyword case-insensitive", config.WatchRule{Keywords: []string{"ivanti", " ransomware "}}, severity.Low, []string{"ivanti", "ransomware"}, true},
+		{"keyword miss", config.WatchRule{Keywords: []string{"fortinet"}}, severity.Low, nil, false},
+		{"regex returns matched text", config.WatchRule{Regexes: []string{`CVE-\d{4}-\d+`}}, severity.Low, []string{"CVE-2026-1234"}, true},
+		{"keyword and regex", config.WatchRule{Keywords: []string{"패치"}, Regexes: []string{`(?i)vpn`}}, severity.Low, []string{"패치", "VPN"}, true},
+		{"source filter hit", config.WatchRule{Keywords: []string{"VPN"}, Sources: []string{"krcert", "boannews"}}, severity.Low, []string{"VPN"}, true},
+		{"source filter miss", config.WatchRule{Keywords: []string{"VPN"}, Sources: []string{"krcert"}}, severity.Critical, nil, false},
+		{"below min severity", config.WatchRule{Keywords: []string{"VPN"}, MinSeverity: "high"}, severity.Medium, nil, false},
+		{"at min severity", config.WatchRule{Keywords: []string{"VPN"}, MinSeverity: "high"}, severity.High, []string{"VPN"}, true},
+		{"conditions only", config.WatchRule{MinSeverity: "critical"}, severity.Critical, nil, true},
+		{"conditions only miss", config.WatchRule{MinSeverity: "critical"}, severity.High, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := compileRule(tt.rule, []string{"ops"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := r.match(article, tt.level)
+			if ok != tt.ok || !slices.Equal(got, tt.want) {
+				t.Errorf("match = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCompileRule(t *testing.T) {
+	r, err := compileRule(config.WatchRule{Name: "vpn"}, []string{"ops", "sec"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(r.webhooks, []string{"ops", "sec"}) {
+		t.Errorf("웹훅 = %v, want 모든 웹훅", r.webhooks)
+	}
+	if _, err := compileRule(config.WatchRule{Regexes: []string{"("}}, nil); err == nil {
+		t.Error("잘못된 정규식에 오류가 없음")
+	}
+	if _, err := compileRule(config.WatchRule{MinSeverity: "urgent"}, nil); err == nil {
+		t.Error("잘못된 심각도에 오류가 없음")
+	}
+}
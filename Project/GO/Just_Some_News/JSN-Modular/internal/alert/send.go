@@ -0,0 +1,90 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"jsn-modular/internal/backoff"
+)
+
+// maxRetryDelay 는 재시도 대기 시간의 상한입니다. Retry-After 도 이 값으로 제한합니다.
+const maxRetryDelay = time.Minute
+
+// statusError 는 웹훅이 2xx 가 아닌 응답을 준 경우입니다.
+type statusError struct {
+	Code int
+	Body string
+}
+
+func (e *statusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("HTTP %d", e.Code)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.Code, e.Body)
+}
+
+// post 는 본문을 웹훅으로 보냅니다. 네트워크 오류, 429, 5xx 는 지수 백오프로 재시도하며
+// 시도 횟수와 마지막 오류를 반환합니다. 오류 메시지에는 URL(토큰)을 넣지 않습니다.
+func (n *Notifier) post(ctx context.Context, target string, body []byte) (int, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		var wait time.Duration
+		var retry bool
+		wait, retry, err = n.try(ctx, target, body)
+		if err == nil {
+			return attempt, nil
+		}
+		if !retry || attempt >= n.maxAttempts {
+			return attempt, err
+		}
+		if wait == 0 {
+			wait = backoff.Delay(attempt, n.retryDelay, maxRetryDelay)
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// try 는 한 번 전송합니다. 재시도할 만한 실패면 retry 가 true 이고, wait 는 Retry-After 값입니다.
+func (n *Notifier) try(ctx context.Context, target string, body []byte) (wait time.Duration, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("웹훅 요청 생성 실패")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "jsn-alert")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// *url.Error 는 URL 을 담고 있으므로 원인만 남김
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			err = ue.Err
+		}
+		return 0, ctx.Err() == nil, fmt.Errorf("웹훅 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, false, nil
+	}
+
+	err = &statusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	if !backoff.RetryableStatus(resp.StatusCode) {
+		return 0, false, err
+	}
+	if d, ok := backoff.RetryAfter(resp.Header, time.Now()); ok {
+		wait = min(max(d, time.Millisecond), maxRetryDelay)
+	}
+	return wait, true, err
+}
@@ -0,0 +1,107 @@
+// Package api serves collected articles over HTTP as JSON.
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/internal/store"
+)
+
+// Article 은 API 와 jsn export 가 내보내는 기사 표현입니다.
+type Article struct {
+	ID              int64     `json:"id"`
+	Source          string    `json:"source"`
+	Title           string    `json:"title"`
+	Link            string    `json:"link"`
+	PubDate         time.Time `json:"pub_date"`
+	DateGuessed     bool      `json:"date_guessed,omitempty"` // 피드에 날짜가 없거나 해석하지 못해 수집 시각으로 대체
+	Description     string    `json:"description,omitempty"`  // 허용 목록으로 정리한 HTML
+	DescriptionText string    `json:"description_text,omitempty"`
+	Content         string    `json:"content,omitempty"`
+	WordCount       int       `json:"word_count,omitempty"`
+	ClusterID       int64     `json:"cluster_id,omitempty"`
+	CollectedAt     time.Time `json:"collected_at"`
+}
+
+// NewArticle 은 저장소 기사를 API 표현으로 바꿉니다.
+func NewArticle(a store.Article) Article {
+	return Article{
+		ID:              a.ID,
+		Source:          a.Source,
+		Title:           a.Title,
+		Link:            a.Link,
+		PubDate:         a.PubDate.UTC(),
+		DateGuessed:     a.DateGuessed,
+		Description:     a.Description,
+		DescriptionText: a.DescriptionText,
+		Content:         a.Content,
+		WordCount:       a.WordCount,
+		ClusterID:       a.ClusterID,
+		CollectedAt:     a.CollectedAt.UTC(),
+	}
+}
+
+// Source 는 출처(피드)별 기사 통계입니다.
+type Source struct {
+	Source        string    `json:"source"`
+	Articles      int64     `json:"articles"`
+	Oldest        time.Time `json:"oldest"`
+	Newest        time.Time `json:"newest"`
+	LastCollected time.Time `json:"last_collected,omitzero"`
+}
+
+// NewSource 는 저장소 통계를 API 표현으로 바꿉니다.
+func NewSource(s store.SourceStats) Source {
+	return Source{
+		Source:        s.Source,
+		Articles:      s.Articles,
+		Oldest:        s.Oldest.UTC(),
+		Newest:        s.Newest.UTC(),
+		LastCollected: s.LastCollected.UTC(),
+	}
+}
+
+// ParseTime 은 날짜(2006-01-02, 현지 자정), 날짜 시각(2006-01-02 15:04) 또는 RFC 3339 시각을 해석합니다.
+// API 의 since/until 과 CLI 의 --since/--until 이 같은 형식을 씁니다.
+func ParseTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "2006-01-02 15:04", time.DateTime} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("시각 형식 오류 (2006-01-02, 2006-01-02 15:04 또는 RFC 3339): %q", s)
+	}
+	return t, nil
+}
+
+var errCursor = errors.New("잘못된 cursor")
+
+// encodeCursor 는 (pubDate, id) 위치를 URL 에 넣을 수 있는 불투명 문자열로 만듭니다.
+func encodeCursor(c store.Cursor) string {
+	raw := strconv.FormatInt(c.PubDate.UnixNano(), 10) + "." + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (*store.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, errCursor
+	}
+	nanos, err1 := strconv.ParseInt(ts, 10, 64)
+	n, err2 := strconv.ParseInt(id, 10, 64)
+	if err1 != nil || err2 != nil {
+		return nil, errCursor
+	}
+	return &store.Cursor{PubDate: time.Unix(0, nanos).UTC(), ID: n}, nil
+}
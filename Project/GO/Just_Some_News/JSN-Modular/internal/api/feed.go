@@ -0,0 +1,282 @@
+package api
+
+import (
+	"encoding/xml"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+// feedFormat 은 재배포 피드 형식입니다.
+type feedFormat int
+
+const (
+	rssFormat feedFormat = iota
+	atomFormat
+)
+
+const generator = "jsn"
+
+// handleFeed 는 수집한 기사를 최신순 RSS 2.0 / Atom 1.0 피드로 내보냅니다.
+//
+//	q=CVE                  제목/설명 부분 일치
+//	source=boannews        출처
+//	saved=이름             publish.queries 의 저장된 조건 (q, source 와 함께 쓸 수 없음)
+//	limit=50               기사 수 (기본 publish.limit, 최대 500)
+func (s *Server) handleFeed(format feedFormat) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		sq := config.SavedQuery{Q: params.Get("q"), Source: params.Get("source")}
+		if name := params.Get("saved"); name != "" {
+			if params.Has("q") || params.Has("source") {
+				writeError(w, http.StatusBadRequest, "saved 는 q, source 와 함께 쓸 수 없음")
+				return
+			}
+			i := slices.IndexFunc(s.pub.Queries, func(q config.SavedQuery) bool { return q.Name == name })
+			if i < 0 {
+				writeError(w, http.StatusNotFound, "저장된 검색 조건이 없음: "+name)
+				return
+			}
+			sq = s.pub.Queries[i]
+		}
+		limit, err := limitParam(params, s.pub.Limit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		articles, err := s.st.Query(r.Context(), store.Query{Source: sq.Source, Keyword: sq.Q, Limit: limit})
+		if err != nil {
+			log.Printf(">>> 피드 기사 조회 실패: %v", err)
+			writeError(w, http.StatusInternalServerError, "기사 조회 실패")
+			return
+		}
+
+		base := s.baseURL(r)
+		// 쿼리 매개변수를 정렬한 주소를 self 링크와 Atom 피드 ID 로 사용
+		self := base + r.URL.Path
+		if len(params) > 0 {
+			self += "?" + params.Encode()
+		}
+		f := feed{title: feedTitle(s.pub.Title, sq), base: base, self: self, articles: articles}
+
+		var doc any
+		contentType := "application/rss+xml; charset=utf-8"
+		if format == atomFormat {
+			doc, contentType = f.atom(), "application/atom+xml; charset=utf-8"
+		} else {
+			doc = f.rss()
+		}
+		body, err := xml.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			log.Printf(">>> 피드 직렬화 실패: %v", err)
+			writeError(w, http.StatusInternalServerError, "피드 직렬화 실패")
+			return
+		}
+		writeCached(w, r, contentType, append([]byte(xml.Header), append(body, '\n')...))
+	}
+}
+
+// baseURL 은 publish.base_url, 없으면 요청의 Host 로 만든 외부 주소입니다 (끝의 / 제외).
+func (s *Server) baseURL(r *http.Request) string {
+	if s.pub.BaseURL != "" {
+		return strings.TrimSuffix(s.pub.BaseURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// feedTitle 은 저장된 조건의 제목, 없으면 기본 제목에 조건을 붙인 제목입니다.
+func feedTitle(title string, q config.SavedQuery) string {
+	if q.Title != "" {
+		return q.Title
+	}
+	var filters []string
+	if q.Q != "" {
+		filters = append(filters, strconv.Quote(q.Q))
+	}
+	if q.Source != "" {
+		filters = append(filters, "출처 "+q.Source)
+	}
+	if len(filters) == 0 {
+		return title
+	}
+	return title + " - " + strings.Join(filters, ", ")
+}
+
+// feed 는 형식과 무관한 재배포 피드 내용입니다.
+type feed struct {
+	title    string
+	base     string
+	self     string
+	articles []store.Article // 최신순
+}
+
+// updated 는 가장 최근 기사의 발행 시각입니다. 같은 내용이면 같은 본문(ETag)이 나오도록 현재 시각을 쓰지 않습니다.
+func (f feed) updated() time.Time {
+	if len(f.articles) == 0 {
+		return time.Unix(0, 0).UTC()
+	}
+	return f.articles[0].PubDate.UTC()
+}
+
+// guid 는 기사의 영구 식별자입니다 (RFC 4151 tag URI).
+// 링크는 upsert 로 바뀔 수 있어 쓰지 않고, 외부 주소의 호스트와 수집 날짜, 기사 id 로 만듭니다.
+//
+//	tag:news.example.com,2026-10-16:article/123
+func (f feed) guid(a store.Article) string {
+	host := f.base
+	if u, err := url.Parse(f.base); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	day := a.CollectedAt
+	if day.IsZero() {
+		day = a.PubDate
+	}
+	return "tag:" + host + "," + day.UTC().Format(time.DateOnly) + ":article/" + strconv.FormatInt(a.ID, 10)
+}
+
+// RSS 2.0 (https://www.rssboard.org/rss-specification)
+type rssDoc struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	Generator     string    `xml:"generator"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Self          rssSelf   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+// rssSelf 는 RSS 검증기가 권장하는 atom:link rel="self" 입니다.
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"` // HTML (XML 이스케이프됨)
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func (f feed) rss() rssDoc {
+	ch := rssChannel{
+		Title:         f.title,
+		Link:          f.base + "/",
+		Description:   f.title + " (수집한 보안 뉴스 재배포)",
+		Language:      "ko",
+		Generator:     generator,
+		LastBuildDate: f.updated().Format(time.RFC1123Z),
+		Self:          rssSelf{Href: f.self, Rel: "self", Type: "application/rss+xml"},
+	}
+	for _, a := range f.articles {
+		ch.Items = append(ch.Items, rssItem{
+			Title:       a.Title,
+			Link:        a.Link,
+			Description: a.Description,
+			GUID:        rssGUID{Value: f.guid(a)},
+			PubDate:     a.PubDate.UTC().Format(time.RFC1123Z),
+			Category:    a.Source,
+		})
+	}
+	return rssDoc{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom", Channel: ch}
+}
+
+// Atom 1.0 (RFC 4287)
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Author    atomAuthor  `xml:"author"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title     string        `xml:"title"`
+	ID        string        `xml:"id"`
+	Links     []atomLink    `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Summary   *atomText     `xml:"summary,omitempty"`
+	Category  *atomCategory `xml:"category,omitempty"` // RFC 4287: term 은 비어 있으면 안 되므로 출처가 없으면 생략
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func (f feed) atom() atomFeed {
+	out := atomFeed{
+		Title:   f.title,
+		ID:      f.self,
+		Updated: f.updated().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.self, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.base + "/", Rel: "alternate"},
+		},
+		Author:    atomAuthor{Name: f.title},
+		Generator: generator,
+	}
+	for _, a := range f.articles {
+		e := atomEntry{
+			Title:     a.Title,
+			ID:        f.guid(a),
+			Links:     []atomLink{{Href: a.Link, Rel: "alternate"}},
+			Published: a.PubDate.UTC().Format(time.RFC3339),
+			Updated:   a.PubDate.UTC().Format(time.RFC3339),
+		}
+		if a.Source != "" {
+			e.Category = &atomCategory{Term: a.Source}
+		}
+		if a.Description != "" {
+			e.Summary = &atomText{Type: "html", Text: a.Description}
+		}
+		out.Entries = append(out.Entries, e)
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+// newTestServer 는 기사를 넣은 메모리 저장소로 Server 를 만듭니다.
+func newTestServer(t *testing.T, pub config.Publish, articles ...store.Article) *Server {
+	t.Helper()
+	st := store.NewMemory(store.Options{})
+	for i, a := range articles {
+		if a.Canonical == "" {
+			a.Canonical = a.Link
+		}
+		if _, err := st.Insert(context.Background(), a); err != nil {
+			t.Fatalf("기사 %d 저장: %v", i, err)
+		}
+	}
+	return New(st, pub)
+}
+
+// get 은 요청 하나를 보내고 응답을 반환합니다.
+func get(t *testing.T, h http.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func sampleArticles() []store.Article {
+	day := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	return []store.Article{
+		{Source: "boannews", Title: "랜섬웨어 조직 검거", Link: "https://example.com/a", PubDate: day, CollectedAt: day,
+			Description: "<p>경찰이 <b>검거</b></p>", DescriptionText: "경찰이 검거"},
+		{Source: "dailysecu", Title: "CVE-2026-1234 패치", Link: "https://example.com/b", PubDate: day.Add(time.Hour), CollectedAt: day},
+		{Title: "출처 없는 기사", Link: "https://example.com/c", PubDate: day.Add(2 * time.Hour), CollectedAt: day},
+	}
+}
+
+func TestFeedRSS(t *testing.T) {
+	s := newTestServer(t, config.Publish{Title: "JSN", BaseURL: "https://news.example.com/"}, sampleArticles()...)
+	rec := get(t, s, "/feeds/rss?source=boannews")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("상태 = %d, 본문 %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/rss+xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var doc rssDoc
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("RSS 파싱: %v\n%s", err, rec.Body)
+	}
+	ch := doc.Channel
+	if doc.Version != "2.0" || ch.Title != "JSN - 출처 boannews" {
+		t.Errorf("채널 = %q %q", doc.Version, ch.Title)
+	}
+	// link 와 atom:link 는 Unmarshal 에서 구분되지 않아 본문으로 확인
+	for _, want := range []string{
+		"<link>https://news.example.com/</link>",
+		`<atom:link href="https://news.example.com/feeds/rss?source=boannews" rel="self"`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("%s 없음:\n%s", want, rec.Body)
+		}
+	}
+	if len(ch.Items) != 1 {
+		t.Fatalf("항목 %d개, want 1", len(ch.Items))
+	}
+	it := ch.Items[0]
+	if it.Title != "랜섬웨어 조직 검거" || it.Category != "boannews" || it.Description != "<p>경찰이 <b>검거</b></p>" {
+		t.Errorf("항목 = %+v", it)
+	}
+	if want := "tag:news.example.com,2026-10-16:article/1"; it.GUID.Value != want || it.GUID.IsPermaLink {
+		t.Errorf("guid = %+v, want %q", it.GUID, want)
+	}
+	if it.PubDate != "Fri, 16 Oct 2026 09:00:00 +0000" {
+		t.Errorf("pubDate = %q", it.PubDate)
+	}
+}
+
+func TestFeedAtom(t *testing.T) {
+	s := newTestServer(t, config.Publish{Title: "JSN"}, sampleArticles()...)
+	rec := get(t, s, "http://jsn.local/feeds/atom?limit=2")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("상태 = %d, 본문 %s", rec.Code, rec.Body)
+	}
+	var doc atomFeed
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Atom 파싱: %v\n%s", err, rec.Body)
+	}
+	if doc.Title != "JSN" || doc.ID != "http://jsn.local/feeds/atom?limit=2" {
+		t.Errorf("피드 = %q %q", doc.Title, doc.ID)
+	}
+	// 가장 최근 기사 시각 (같은 내용이면 같은 ETag)
+	if doc.Updated != "2026-10-16T11:00:00Z" {
+		t.Errorf("updated = %q", doc.Updated)
+	}
+	if len(doc.Entries) != 2 {
+		t.Fatalf("항목 %d개, want 2", len(doc.Entries))
+	}
+	if doc.Entries[0].Title != "출처 없는 기사" || doc.Entries[1].Title != "CVE-2026-1234 패치" {
+		t.Errorf("항목 순서 = %q, %q", doc.Entries[0].Title, doc.Entries[1].Title)
+	}
+
+	// RFC 4287: 빈 term 의 category 를 내보내면 안 됨
+	body := rec.Body.String()
+	if strings.Contains(body, `term=""`) {
+		t.Errorf("빈 category 가 있음:\n%s", body)
+	}
+	if c := doc.Entries[1].Category; c == nil || c.Term != "dailysecu" {
+		t.Errorf("category = %+v, want dailysecu", c)
+	}
+	if doc.Entries[0].Category != nil {
+		t.Errorf("출처 없는 기사의 category = %+v", doc.Entries[0].Category)
+	}
+}
+
+func TestFeedSavedQuery(t *testing.T) {
+	pub := config.Publish{Title: "JSN", Queries: []config.SavedQuery{
+		{Name: "cve", Q: "cve"},
+		{Name: "boan", Title: "보안뉴스", Source: "boannews"},
+	}}
+	s := newTestServer(t, pub, sampleArticles()...)
+
+	tests := []struct {
+		target    string
+		wantCode  int
+		wantTitle string
+		wantItems int
+	}{
+		{"/feeds/rss?saved=cve", http.StatusOK, `JSN - "cve"`, 1},
+		{"/feeds/rss?saved=boan", http.StatusOK, "보안뉴스", 1},
+		{"/feeds/rss", http.StatusOK, "JSN", 3},
+		{"/feeds/rss?saved=cve&q=x", http.StatusBadRequest, "", 0},
+		{"/feeds/atom?saved=cve&source=boannews", http.StatusBadRequest, "", 0},
+		{"/feeds/rss?saved=nope", http.StatusNotFound, "", 0},
+		{"/feeds/rss?limit=abc", http.StatusBadRequest, "", 0},
+	}
+	for _, tt := range tests {
+		rec := get(t, s, tt.target)
+		if rec.Code != tt.wantCode {
+			t.Errorf("%s 상태 = %d, want %d", tt.target, rec.Code, tt.wantCode)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		var doc rssDoc
+		if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+			t.Errorf("%s 파싱: %v", tt.target, err)
+			continue
+		}
+		if doc.Channel.Title != tt.wantTitle || len(doc.Channel.Items) != tt.wantItems {
+			t.Errorf("%s = %q (%d건), want %q (%d건)", tt.target, doc.Channel.Title, len(doc.Channel.Items), tt.wantTitle, tt.wantItems)
+		}
+	}
+}
+
+func TestFeedETag(t *testing.T) {
+	s := newTestServer(t, config.Publish{Title: "JSN"}, sampleArticles()...)
+	for _, path := range []string{"/feeds/rss", "/feeds/atom"} {
+		first := get(t, s, path)
+		etag := first.Header().Get("ETag")
+		if first.Code != http.StatusOK || etag == "" {
+			t.Fatalf("%s 상태 = %d, ETag = %q", path, first.Code, etag)
+		}
+		if again := get(t, s, path); again.Header().Get("ETag") != etag {
+			t.Errorf("%s 같은 내용인데 ETag 가 바뀜: %q → %q", path, etag, again.Header().Get("ETag"))
+		}
+		rec := get(t, s, path, "If-None-Match", fmt.Sprintf(`"other", %s`, etag))
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Errorf("%s If-None-Match 상태 = %d, 본문 %d바이트, want 304", path, rec.Code, rec.Body.Len())
+		}
+	}
+}
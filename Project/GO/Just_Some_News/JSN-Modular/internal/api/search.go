@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+
+	"jsn-modular/internal/search"
+	"jsn-modular/internal/store"
+)
+
+// snippetWidth 는 검색 결과 스니펫의 글자 수입니다.
+const snippetWidth = 160
+
+// SearchHit 는 전문 검색 결과 하나입니다. 본문(content)은 빼고 강조 표시한 제목과 스니펫을 붙입니다.
+type SearchHit struct {
+	Article
+	Score     float64 `json:"score"`
+	TitleHTML string  `json:"title_html"`   // 검색어를 <mark> 로 감싼 제목 (HTML 이스케이프됨)
+	Snippet   string  `json:"snippet_html"` // 설명에서 검색어 부근을 자른 스니펫 (HTML 이스케이프됨)
+}
+
+// NewSearchHit 는 저장소 검색 결과를 API 표현으로 바꿉니다. q 는 강조 표시할 검색식입니다.
+func NewSearchHit(h store.SearchHit, q search.Query) SearchHit {
+	a := h.Article
+	a.Content = ""
+	return SearchHit{
+		Article:   NewArticle(a),
+		Score:     h.Score,
+		TitleHTML: search.Highlight(a.Title, q, 0).Format("<mark>", "</mark>", html.EscapeString),
+		Snippet:   search.Highlight(a.DescriptionText, q, snippetWidth).Format("<mark>", "</mark>", html.EscapeString),
+	}
+}
+
+// searchResult 는 GET /api/search 응답입니다.
+type searchResult struct {
+	Hits       []SearchHit `json:"hits"`
+	NextOffset int         `json:"next_offset,omitempty"` // 다음 페이지가 없으면 생략
+}
+
+// handleSearch 는 전문 검색 결과를 관련도 순으로 반환합니다.
+//
+//	q=랜섬웨어 공격         검색어 (필수, 공백으로 구분한 모든 검색어를 포함)
+//	source, since, until   /api/articles 와 같음
+//	limit=50               페이지 크기 (1~500)
+//	offset=0               이전 응답의 next_offset
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	text := params.Get("q")
+	if text == "" {
+		writeError(w, http.StatusBadRequest, "q 가 필요함")
+		return
+	}
+	sq, err := search.ParseQuery(text)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	q := store.SearchQuery{Text: text, Source: params.Get("source")}
+	if q.Limit, err = limitParam(params, defaultLimit); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if v := params.Get("offset"); v != "" {
+		if q.Offset, err = strconv.Atoi(v); err != nil || q.Offset < 0 {
+			writeError(w, http.StatusBadRequest, "offset 은 0 이상의 정수여야 함")
+			return
+		}
+	}
+	if q.Since, err = timeParam(params, "since"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if q.Until, err = timeParam(params, "until"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 한 건 더 읽어 다음 페이지가 있는지 확인
+	limit := q.Limit
+	q.Limit++
+	hits, err := s.st.Search(r.Context(), q)
+	switch {
+	case errors.Is(err, search.ErrShortQuery):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		log.Printf(">>> API 검색 실패: %v", err)
+		writeError(w, http.StatusInternalServerError, "검색 실패")
+		return
+	}
+
+	resp := searchResult{Hits: make([]SearchHit, 0, min(len(hits), limit))}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		resp.NextOffset = q.Offset + limit
+		setNextLink(w, r, "offset", strconv.Itoa(resp.NextOffset))
+	}
+	for _, h := range hits {
+		resp.Hits = append(resp.Hits, NewSearchHit(h, sq))
+	}
+	writeJSON(w, r, resp)
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// Server 는 읽기 전용 HTTP API 입니다.
+//
+//	GET /healthz              상태 확인
+//	GET /api/articles         기사 목록 (최신순, cursor 페이지네이션, 본문 제외)
+//	GET /api/articles/{id}    기사 하나 (본문 포함)
+//	GET /api/search           전문 검색 (관련도순, 강조 표시한 스니펫)
+//	GET /api/sources          출처별 기사 통계
+//	GET /feeds/rss            수집한 기사를 다시 내보내는 RSS 2.0 피드 (최신순)
+//	GET /feeds/atom           같은 내용의 Atom 1.0 피드
+//
+// 모든 200 응답에 본문 해시로 만든 ETag 를 붙이고, If-None-Match 가 맞으면 304 로 답합니다.
+type Server struct {
+	st  store.ArticleStore
+	pub config.Publish
+	mux *http.ServeMux
+}
+
+// New 는 st 를 읽는 API 서버를 만듭니다. pub 은 /feeds 재배포 피드 설정입니다.
+func New(st store.ArticleStore, pub config.Publish) *Server {
+	s := &Server{st: st, pub: pub, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /api/articles", s.handleArticles)
+	s.mux.HandleFunc("GET /api/articles/{id}", s.handleArticle)
+	s.mux.HandleFunc("GET /api/search", s.handleSearch)
+	s.mux.HandleFunc("GET /api/sources", s.handleSources)
+	s.mux.HandleFunc("GET /feeds/rss", s.handleFeed(rssFormat))
+	s.mux.HandleFunc("GET /feeds/atom", s.handleFeed(atomFormat))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, map[string]string{"status": "ok"})
+}
+
+// articleList 는 GET /api/articles 응답입니다.
+type articleList struct {
+	Articles   []Article `json:"articles"`
+	NextCursor string    `json:"next_cursor,omitempty"` // 다음 페이지가 없으면 생략
+}
+
+// handleArticles 는 기사 목록을 반환합니다.
+//
+//	source=boannews        출처
+//	q=랜섬웨어              제목/설명 부분 일치
+//	since=2026-10-01       이 시각 이후 (포함, ParseTime 형식)
+//	until=2026-10-08       이 시각 이전 (미포함)
+//	limit=50               페이지 크기 (1~500)
+//	cursor=...             이전 응답의 next_cursor
+func (s *Server) handleArticles(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := store.Query{Source: params.Get("source"), Keyword: params.Get("q")}
+	var err error
+	if q.Limit, err = limitParam(params, defaultLimit); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if q.Since, err = timeParam(params, "since"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if q.Until, err = timeParam(params, "until"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if v := params.Get("cursor"); v != "" {
+		c, err := decodeCursor(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		q.Before = c
+	}
+
+	// 한 건 더 읽어 다음 페이지가 있는지 확인
+	limit := q.Limit
+	q.Limit++
+	articles, err := s.st.Query(r.Context(), q)
+	if err != nil {
+		log.Printf(">>> API 기사 조회 실패: %v", err)
+		writeError(w, http.StatusInternalServerError, "기사 조회 실패")
+		return
+	}
+
+	resp := articleList{Articles: make([]Article, 0, min(len(articles), limit))}
+	if len(articles) > limit {
+		articles = articles[:limit]
+		last := articles[limit-1]
+		resp.NextCursor = encodeCursor(store.Cursor{PubDate: last.PubDate, ID: last.ID})
+		setNextLink(w, r, "cursor", resp.NextCursor)
+	}
+	for _, a := range articles {
+		a.Content = "" // 목록에서는 본문을 빼고 /api/articles/{id} 에서 제공
+		resp.Articles = append(resp.Articles, NewArticle(a))
+	}
+	writeJSON(w, r, resp)
+}
+
+// limitParam 은 limit 쿼리 매개변수를 읽습니다. 없으면 def 입니다.
+func limitParam(params url.Values, def int) (int, error) {
+	v := params.Get("limit")
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > maxLimit {
+		return 0, fmt.Errorf("limit 은 1~%d 사이의 정수여야 함", maxLimit)
+	}
+	return n, nil
+}
+
+// setNextLink 는 요청 URL 의 key 만 value 로 바꾼 다음 페이지 주소를 Link 헤더로 알려 줍니다.
+func setNextLink(w http.ResponseWriter, r *http.Request, key, value string) {
+	next := *r.URL
+	qs := next.Query()
+	qs.Set(key, value)
+	next.RawQuery = qs.Encode()
+	w.Header().Set("Link", "<"+next.RequestURI()+`>; rel="next"`)
+}
+
+// timeParam 은 쿼리 매개변수 name 을 ParseTime 으로 읽습니다. 없으면 0 시각입니다.
+func timeParam(params url.Values, name string) (time.Time, error) {
+	v := params.Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := ParseTime(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return t, nil
+}
+
+func (s *Server) handleArticle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id < 1 {
+		writeError(w, http.StatusBadRequest, "잘못된 기사 id: "+url.PathEscape(r.PathValue("id")))
+		return
+	}
+	a, err := s.st.Get(r.Context(), id)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		log.Printf(">>> API 기사 조회 실패: %v", err)
+		writeError(w, http.StatusInternalServerError, "기사 조회 실패")
+		return
+	}
+	writeJSON(w, r, NewArticle(a))
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.st.Stats(r.Context())
+	if err != nil {
+		log.Printf(">>> API 통계 조회 실패: %v", err)
+		writeError(w, http.StatusInternalServerError, "통계 조회 실패")
+		return
+	}
+	out := make([]Source, len(stats))
+	for i, st := range stats {
+		out[i] = NewSource(st)
+	}
+	writeJSON(w, r, map[string][]Source{"sources": out})
+}
+
+// writeJSON 은 v 를 JSON 200 응답으로 씁니다 (writeCached).
+func writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Printf(">>> API 응답 직렬화 실패: %v", err)
+		writeError(w, http.StatusInternalServerError, "응답 직렬화 실패")
+		return
+	}
+	writeCached(w, r, "application/json; charset=utf-8", append(body, '\n'))
+}
+
+// writeCached 는 200 응답을 본문 해시로 만든 ETag 와 함께 씁니다.
+// 클라이언트의 If-None-Match 가 같으면 본문 없이 304 로 답합니다.
+func writeCached(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	// 캐시는 하되 매번 ETag 로 재검증 (수집 주기마다 내용이 바뀜)
+	h.Set("Cache-Control", "no-cache")
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// etagMatch 는 If-None-Match 목록에 etag 가 있는지 약한 비교로 확인합니다.
+func etagMatch(header, etag string) bool {
+	for part := range strings.SplitSeq(header, ",") {
+		part = strings.TrimPrefix(strings.TrimSpace(part), "W/")
+		if part == "*" || part == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
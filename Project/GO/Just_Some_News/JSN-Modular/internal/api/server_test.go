@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := store.Cursor{PubDate: time.Date(2026, 10, 16, 9, 0, 0, 123456789, time.UTC), ID: 42}
+	got, err := decodeCursor(encodeCursor(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.PubDate.Equal(want.PubDate) || got.ID != want.ID {
+		t.Errorf("decodeCursor = %+v, want %+v", *got, want)
+	}
+
+	enc := base64.RawURLEncoding.EncodeToString
+	for _, bad := range []string{
+		"not base64!",
+		enc([]byte("1760605200000000000")), // id 없음
+		enc([]byte("abc.42")),
+		enc([]byte("1760605200000000000.x")),
+		encodeCursor(want) + "=", // 패딩은 RawURL 이 아님
+	} {
+		if _, err := decodeCursor(bad); !errors.Is(err, errCursor) {
+			t.Errorf("decodeCursor(%q) 오류 = %v, want errCursor", bad, err)
+		}
+	}
+}
+
+// manyArticles 는 발행 시각이 겹치는 기사 n개를 만듭니다 (같은 시각은 id 로 순서를 정함).
+func manyArticles(n int) []store.Article {
+	day := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	out := make([]store.Article, n)
+	for i := range out {
+		out[i] = store.Article{
+			Source:      "boannews",
+			Title:       fmt.Sprintf("기사 %d", i+1),
+			Link:        fmt.Sprintf("https://example.com/%d", i+1),
+			PubDate:     day.Add(time.Duration(i/2) * time.Hour),
+			CollectedAt: day,
+			Content:     "본문",
+		}
+	}
+	return out
+}
+
+var nextLink = regexp.MustCompile(`^<([^>]+)>; rel="next"$`)
+
+func TestArticlesPagination(t *testing.T) {
+	s := newTestServer(t, config.Publish{}, manyArticles(7)...)
+
+	var ids []int64
+	target := "/api/articles?source=boannews&limit=3"
+	for page := 0; target != ""; page++ {
+		if page > 5 {
+			t.Fatal("페이지가 끝나지 않음")
+		}
+		rec := get(t, s, target)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s 상태 = %d, 본문 %s", target, rec.Code, rec.Body)
+		}
+		var resp articleList
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		for _, a := range resp.Articles {
+			if a.Content != "" {
+				t.Errorf("목록에 본문이 있음: 기사 %d", a.ID)
+			}
+			ids = append(ids, a.ID)
+		}
+
+		link := rec.Header().Get("Link")
+		if resp.NextCursor == "" {
+			if link != "" {
+				t.Errorf("마지막 페이지에 Link 헤더 %q", link)
+			}
+			target = ""
+			continue
+		}
+		m := nextLink.FindStringSubmatch(link)
+		if m == nil {
+			t.Fatalf("Link 헤더 = %q", link)
+		}
+		// 다른 매개변수는 유지하고 cursor 만 바뀜
+		want := "/api/articles?cursor=" + resp.NextCursor + "&limit=3&source=boannews"
+		if m[1] != want {
+			t.Errorf("다음 주소 = %q, want %q", m[1], want)
+		}
+		target = m[1]
+	}
+
+	// 최신순, 같은 시각이면 id 내림차순으로 빠짐 없이 한 번씩
+	want := []int64{7, 6, 5, 4, 3, 2, 1}
+	if fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("기사 id = %v, want %v", ids, want)
+	}
+}
+
+func TestArticlesBadRequest(t *testing.T) {
+	s := newTestServer(t, config.Publish{}, manyArticles(2)...)
+	tampered := base64.RawURLEncoding.EncodeToString([]byte("yesterday.1"))
+	for _, target := range []string{
+		"/api/articles?cursor=" + tampered,
+		"/api/articles?cursor=abc!",
+		"/api/articles?limit=0",
+		"/api/articles?limit=501",
+		"/api/articles?since=어제",
+		"/api/articles/abc",
+		"/api/articles/0",
+	} {
+		rec := get(t, s, target)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s 상태 = %d, want 400", target, rec.Code)
+			continue
+		}
+		if cc := rec.Header().Get("Cache-Control"); cc != "no-store" {
+			t.Errorf("%s Cache-Control = %q, want no-store", target, cc)
+		}
+	}
+	if rec := get(t, s, "/api/articles/99"); rec.Code != http.StatusNotFound {
+		t.Errorf("없는 기사 상태 = %d, want 404", rec.Code)
+	}
+}
+
+func TestArticleETag(t *testing.T) {
+	s := newTestServer(t, config.Publish{}, manyArticles(2)...)
+
+	rec := get(t, s, "/api/articles/1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("상태 = %d, 본문 %s", rec.Code, rec.Body)
+	}
+	var a Article
+	if err := json.Unmarshal(rec.Body.Bytes(), &a); err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != 1 || a.Content != "본문" {
+		t.Errorf("기사 = %+v", a)
+	}
+	etag := rec.Header().Get("ETag")
+	if cc := rec.Header().Get("Cache-Control"); etag == "" || cc != "no-cache" {
+		t.Fatalf("ETag = %q, Cache-Control = %q", etag, cc)
+	}
+
+	tests := []struct {
+		ifNoneMatch string
+		want        int
+	}{
+		{etag, http.StatusNotModified},
+		{"W/" + etag, http.StatusNotModified},
+		{`"a", ` + etag, http.StatusNotModified},
+		{"*", http.StatusNotModified},
+		{`"a"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		rec := get(t, s, "/api/articles/1", "If-None-Match", tt.ifNoneMatch)
+		if rec.Code != tt.want {
+			t.Errorf("If-None-Match %q 상태 = %d, want %d", tt.ifNoneMatch, rec.Code, tt.want)
+		}
+		if rec.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %q ETag = %q, want %q", tt.ifNoneMatch, rec.Header().Get("ETag"), etag)
+		}
+	}
+
+	// 다른 기사는 다른 ETag
+	if other := get(t, s, "/api/articles/2").Header().Get("ETag"); other == etag {
+		t.Errorf("다른 기사가 같은 ETag %q", other)
+	}
+}
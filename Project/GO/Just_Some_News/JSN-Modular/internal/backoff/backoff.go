@@ -0,0 +1,46 @@
+// Package backoff computes retry delays for HTTP requests shared by the feed collector and alert webhooks.
+package backoff
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryableStatus 는 재시도할 가치가 있는 HTTP 상태(429, 5xx)인지 확인합니다.
+func RetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// Delay 는 attempt 번째(1부터) 실패 후의 대기 시간을 계산합니다.
+// base * 2^(attempt-1) 을 limit 로 제한한 뒤 [d/2, d) 범위의 지터를 적용합니다.
+func Delay(attempt int, base, limit time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	d = min(d, limit)
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half)
+}
+
+// RetryAfter 는 Retry-After 헤더(초 또는 HTTP-date)를 대기 시간으로 변환합니다.
+// 이미 지난 시각이면 0 이고, 헤더가 없거나 해석할 수 없으면 false 입니다.
+func RetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	v := strings.TrimSpace(header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
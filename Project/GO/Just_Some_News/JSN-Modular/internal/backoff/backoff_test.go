@@ -0,0 +1,67 @@
+package backoff
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	tests := []struct {
+		attempt     int
+		base, limit time.Duration
+		want        time.Duration // 지터 전 값. 결과는 [want/2, want)
+	}{
+		{1, time.Second, time.Minute, time.Second},
+		{2, time.Second, time.Minute, 2 * time.Second},
+		{4, time.Second, time.Minute, 8 * time.Second},
+		{10, time.Second, time.Minute, time.Minute},
+		{3, 40 * time.Second, time.Minute, time.Minute},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			got := Delay(tt.attempt, tt.base, tt.limit)
+			if got < tt.want/2 || got >= tt.want {
+				t.Fatalf("Delay(%d, %v, %v) = %v, want [%v, %v)", tt.attempt, tt.base, tt.limit, got, tt.want/2, tt.want)
+			}
+		}
+	}
+	if got := Delay(1, time.Nanosecond, time.Minute); got != time.Nanosecond {
+		t.Errorf("Delay 1ns = %v, want 지터 없이 1ns", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"-5", 0, false},
+		{"Fri, 16 Oct 2026 09:00:30 GMT", 30 * time.Second, true},
+		{"Fri, 16 Oct 2026 08:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.header != "" {
+			h.Set("Retry-After", tt.header)
+		}
+		got, ok := RetryAfter(h, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RetryAfter(%q) = %v, %v, want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryableStatus(t *testing.T) {
+	for code, want := range map[int]bool{200: false, 304: false, 404: false, 429: true, 500: true, 503: true} {
+		if got := RetryableStatus(code); got != want {
+			t.Errorf("RetryableStatus(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
@@ -1,10 +0,0 @@
-package config
-
-const (
-	DBUser     = "rl"
-	DBPassword = "rockylinux"
-	DBHost     = "192.168.1.46"
-	DBPort     = 3306
-	DBName     = "read_news"
-	RSSURL     = "https://www.boannews.com/media/news_rss.xml"
-)
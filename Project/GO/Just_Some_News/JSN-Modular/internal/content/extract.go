@@ -0,0 +1,188 @@
+// Package content fetches article pages and extracts their main body text.
+package content
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// minBlockLen 은 본문 단락으로 인정하는 최소 글자 수입니다.
+const minBlockLen = 25
+
+var (
+	// positiveHint 는 class/id 에 있으면 본문일 가능성이 높은 이름입니다.
+	positiveHint = regexp.MustCompile(`(?i)article|body|content|entry|main|news|post|story|text|view`)
+	// negativeHint 는 class/id 에 있으면 본문이 아닐 가능성이 높은 이름입니다.
+	negativeHint = regexp.MustCompile(`(?i)banner|comment|footer|header|menu|nav|related|reply|share|side|social|sponsor|widget|\bad`)
+)
+
+// skipTags 는 본문 후보에서 통째로 제외하는 태그입니다.
+var skipTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true,
+	atom.Form: true, atom.Nav: true, atom.Header: true, atom.Footer: true,
+	atom.Aside: true, atom.Button: true, atom.Select: true, atom.Svg: true,
+}
+
+// blockTags 는 텍스트 추출 시 줄바꿈으로 구분하는 블록 태그입니다.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Article: true, atom.Section: true,
+	atom.Main: true, atom.Td: true, atom.Li: true, atom.Blockquote: true,
+	atom.Pre: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Tr: true, atom.Table: true, atom.Body: true,
+}
+
+// Extract 는 UTF-8 HTML 문서에서 readability 방식으로 본문 텍스트를 추출합니다.
+// 블록마다 직접 포함한 텍스트 길이와 쉼표 수로 점수를 매기고, 부모에 절반을 더한 뒤
+// class/id 힌트와 링크 밀도로 보정해 가장 높은 블록의 텍스트를 반환합니다.
+func Extract(r io.Reader) (string, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", err
+	}
+
+	scores := make(map[*html.Node]float64)
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && skipTags[n.DataAtom] {
+			return
+		}
+		if n.Type == html.ElementNode && blockTags[n.DataAtom] {
+			if text := ownText(n); utf8.RuneCountInString(text) >= minBlockLen {
+				score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，"))
+				score += min(float64(utf8.RuneCountInString(text))/100, 3)
+				scores[n] += score
+				if p := n.Parent; p != nil && p.Type == html.ElementNode {
+					scores[p] += score / 2
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	var best *html.Node
+	var bestScore float64
+	for n, score := range scores {
+		score = (score + classWeight(n)) * (1 - linkDensity(n))
+		if best == nil || score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+	if best == nil {
+		return "", nil
+	}
+	return blockText(best), nil
+}
+
+// ownText 는 하위 블록을 제외하고 노드가 직접 포함한 (인라인 포함) 텍스트입니다.
+func ownText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.TextNode:
+				sb.WriteString(c.Data)
+			case c.Type == html.ElementNode && !skipTags[c.DataAtom] && !blockTags[c.DataAtom]:
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// classWeight 는 class/id 이름에 따른 가중치입니다.
+func classWeight(n *html.Node) float64 {
+	var w float64
+	for _, a := range n.Attr {
+		if a.Key != "class" && a.Key != "id" {
+			continue
+		}
+		if positiveHint.MatchString(a.Val) {
+			w += 25
+		}
+		if negativeHint.MatchString(a.Val) {
+			w -= 25
+		}
+	}
+	return w
+}
+
+// linkDensity 는 하위 텍스트 중 링크 텍스트가 차지하는 비율입니다.
+func linkDensity(n *html.Node) float64 {
+	var total, linked int
+	var walk func(n *html.Node, inLink bool)
+	walk = func(n *html.Node, inLink bool) {
+		if n.Type == html.TextNode {
+			l := utf8.RuneCountInString(strings.TrimSpace(n.Data))
+			total += l
+			if inLink {
+				linked += l
+			}
+			return
+		}
+		if n.Type == html.ElementNode && skipTags[n.DataAtom] {
+			return
+		}
+		inLink = inLink || (n.Type == html.ElementNode && n.DataAtom == atom.A)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, inLink)
+		}
+	}
+	walk(n, false)
+	if total == 0 {
+		return 0
+	}
+	return float64(linked) / float64(total)
+}
+
+// blockText 는 블록 경계와 <br> 을 줄바꿈으로 바꿔 노드의 텍스트를 추출합니다.
+func blockText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			sb.WriteString(n.Data)
+			return
+		case n.Type != html.ElementNode:
+		case skipTags[n.DataAtom]:
+			return
+		case n.DataAtom == atom.Br:
+			sb.WriteByte('\n')
+			return
+		}
+		isBlock := n.Type == html.ElementNode && blockTags[n.DataAtom]
+		if isBlock {
+			sb.WriteByte('\n')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if isBlock {
+			sb.WriteByte('\n')
+		}
+	}
+	walk(n)
+
+	var lines []string
+	for _, line := range strings.Split(sb.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WordCount 는 공백으로 구분된 어절 수를 셉니다.
+func WordCount(text string) int {
+	return len(strings.Fields(text))
+}
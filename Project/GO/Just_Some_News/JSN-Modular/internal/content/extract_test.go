@@ -0,0 +1,142 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestExtractFixtures(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		contentType string
+		status      string
+		text        []string // 줄 단위 본문
+		words       int
+	}{
+		{
+			name:   "boannews EUC-KR meta charset",
+			file:   "boannews_euckr.html",
+			status: StatusOK,
+			text: []string{
+				"[보안뉴스 김보안 기자] 국내 기업에서 널리 쓰이는 VPN 장비에서 인증 없이 원격으로 코드를 실행할 수 있는 취약점이 발견돼 보안 업데이트가 필요하다.",
+				"한국인터넷진흥원은 13일 해당 취약점(CVE-2026-1234)이 관리 페이지의 입력값 검증 오류에서 비롯됐으며, 공격자가 조작한 요청을 보내면 장비 권한을 탈취할 수 있다고 밝혔다.",
+				"제조사는 최신 펌웨어에서 문제를 해결했다며, 관리 페이지를 외부에 노출하지 말고 즉시 업데이트할 것을 권고했다.",
+				"[김보안 기자(boan@boannews.com)]",
+			},
+			words: 57,
+		},
+		{
+			name:   "EUC-KR without declaration",
+			file:   "euckr_nodecl.html",
+			status: StatusOK,
+			text: []string{
+				"문자 집합 선언이 없는 오래된 국내 페이지도 EUC-KR 로 읽어야 한글이 깨지지 않는다.",
+				"이 문단은 본문 추출기가 선택해야 하는 충분히 긴 두 번째 단락이다.",
+			},
+			words: 24,
+		},
+		{
+			name:        "EUC-KR from Content-Type header",
+			file:        "euckr_nodecl.html",
+			contentType: "text/html; charset=euc-kr",
+			status:      StatusOK,
+			text: []string{
+				"문자 집합 선언이 없는 오래된 국내 페이지도 EUC-KR 로 읽어야 한글이 깨지지 않는다.",
+				"이 문단은 본문 추출기가 선택해야 하는 충분히 긴 두 번째 단락이다.",
+			},
+			words: 24,
+		},
+		{
+			name:   "UTF-8 article with nav and sidebar",
+			file:   "utf8_article.html",
+			status: StatusOK,
+			text: []string{
+				"Vendor patches actively exploited flaw",
+				"The vendor released an emergency update on Monday, fixing a flaw that attackers have exploited in the wild since early October.",
+				"Administrators should upgrade to version 7.4.2, restrict access to the management interface, and review logs for signs of compromise.",
+				"보안 업데이트는 한국어 안내문과 함께 배포됐다.",
+			},
+			words: 51,
+		},
+		{
+			name:   "no body candidate",
+			file:   "empty.html",
+			status: StatusEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			r, err := NewUTF8Reader(f, tt.contentType)
+			if err != nil {
+				t.Fatalf("NewUTF8Reader: %v", err)
+			}
+			res := ExtractResult(r)
+			if res.Status != tt.status {
+				t.Fatalf("status = %q, want %q (err %v)", res.Status, tt.status, res.Err)
+			}
+			if want := strings.Join(tt.text, "\n"); res.Text != want {
+				t.Errorf("text =\n%s\nwant\n%s", res.Text, want)
+			}
+			if res.WordCount != tt.words {
+				t.Errorf("word count = %d, want %d", res.WordCount, tt.words)
+			}
+		})
+	}
+}
+
+// EUC-KR 원본 바이트가 UTF-8 이 아니고, 변환 결과가 깨진 글자 없이 한글로 읽히는지 확인합니다.
+func TestNewUTF8ReaderDecodesEUCKR(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"boannews_euckr.html", "한국인터넷진흥원은 13일 해당 취약점"},
+		{"euckr_nodecl.html", "한글이 깨지지 않는다"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if utf8.Valid(raw) {
+				t.Fatal("fixture is valid UTF-8, want EUC-KR bytes")
+			}
+
+			r, err := NewUTF8Reader(strings.NewReader(string(raw)), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := ExtractResult(r)
+			if !utf8.ValidString(res.Text) || strings.ContainsRune(res.Text, utf8.RuneError) {
+				t.Fatalf("decoded text has invalid runes: %q", res.Text)
+			}
+			if !strings.Contains(res.Text, tt.want) {
+				t.Errorf("text does not contain %q: %q", tt.want, res.Text)
+			}
+		})
+	}
+}
+
+func TestNewUTF8ReaderKeepsUTF8(t *testing.T) {
+	const page = `<html><head><meta charset="utf-8"></head><body><p>한글 본문은 그대로 읽혀야 하는 충분히 긴 단락입니다.</p></body></html>`
+	r, err := NewUTF8Reader(strings.NewReader(page), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := ExtractResult(r)
+	if res.Text != "한글 본문은 그대로 읽혀야 하는 충분히 긴 단락입니다." {
+		t.Errorf("text = %q", res.Text)
+	}
+}
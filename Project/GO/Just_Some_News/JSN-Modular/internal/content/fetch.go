@@ -0,0 +1,93 @@
+package content
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding/korean"
+)
+
+// maxPageSize 는 기사 페이지 본문을 읽는 최대 바이트 수입니다.
+const maxPageSize = 5 << 20
+
+// 수집 상태 값 (security_articles.content_status)
+const (
+	StatusOK         = "ok"          // 본문 추출 성공
+	StatusEmpty      = "empty"       // 페이지는 받았으나 본문 후보 없음
+	StatusHTTPError  = "http_error"  // 200 이외의 응답
+	StatusFetchError = "fetch_error" // 네트워크/읽기 오류
+	StatusParseError = "parse_error" // HTML 파싱 오류
+)
+
+// Result 는 기사 페이지 하나의 본문 수집 결과입니다.
+type Result struct {
+	Text      string
+	WordCount int
+	Status    string
+	Err       error
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Fetch 는 기사 페이지를 내려받아 UTF-8 로 변환한 뒤 본문을 추출합니다.
+func Fetch(ctx context.Context, url string) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Result{Status: StatusFetchError, Err: err}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Result{Status: StatusFetchError, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Result{Status: StatusHTTPError, Err: fmt.Errorf("HTTP %s", resp.Status)}
+	}
+
+	body, err := NewUTF8Reader(io.LimitReader(resp.Body, maxPageSize), resp.Header.Get("Content-Type"))
+	if err != nil {
+		return Result{Status: StatusFetchError, Err: err}
+	}
+	return ExtractResult(body)
+}
+
+// ExtractResult 는 UTF-8 HTML 에서 본문을 추출해 Result 로 정리합니다.
+// 저장해 둔 HTML 파일을 그대로 넘겨 오프라인에서 확인할 수 있습니다.
+func ExtractResult(r io.Reader) Result {
+	text, err := Extract(r)
+	if err != nil {
+		return Result{Status: StatusParseError, Err: err}
+	}
+	if text == "" {
+		return Result{Status: StatusEmpty}
+	}
+	return Result{Text: text, WordCount: WordCount(text), Status: StatusOK}
+}
+
+// NewUTF8Reader 는 BOM, Content-Type, <meta charset> 순으로 페이지 인코딩을 판별해
+// UTF-8 리더를 반환합니다. 선언이 없고 UTF-8 도 아니면 EUC-KR(CP949)로 간주합니다.
+func NewUTF8Reader(r io.Reader, contentType string) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, 4096)
+	peek, err := br.Peek(1024)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	enc, name, certain := charset.DetermineEncoding(peek, contentType)
+	if !certain && name == "windows-1252" && !bytes.Contains(bytes.ToLower(peek), []byte("charset")) {
+		// 선언이 전혀 없어 기본값(windows-1252)으로 떨어진 경우.
+		// boannews 등 국내 구형 페이지는 선언 없이 EUC-KR 을 쓰는 경우가 있음
+		enc, name = korean.EUCKR, "euc-kr"
+	}
+	if name == "utf-8" {
+		return br, nil
+	}
+	return enc.NewDecoder().Reader(br), nil
+}
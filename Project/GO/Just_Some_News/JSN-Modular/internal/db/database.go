@@ -1,36 +1,55 @@
+// Package db opens database connections for each SQL storage backend.
 package db
 
 import (
 	"database/sql"
 	"fmt"
-	"jsn-modular/internal/config"
+	"net"
+	"strconv"
+	"strings"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
-func InitDB() (*sql.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?parseTime=true",
-		config.DBUser, config.DBPassword, config.DBHost, config.DBPort)
+// MySQLConfig 는 MariaDB 접속 정보입니다 (드라이버는 go-sql-driver/mysql).
+type MySQLConfig struct {
+	User     string
+	Password string
+	Host     string
+	Port     int
+	Name     string
+	// Create 면 연결 전에 데이터베이스가 없으면 만듭니다. 조회 전용 연결은 만들지 않습니다.
+	Create bool
+}
 
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		return nil, err
-	}
+// OpenMySQL 은 (cfg.Create 면 데이터베이스가 없을 때 만든 뒤) 그 데이터베이스를 DSN 에 지정해 연결합니다.
+// 테이블은 migrate 패키지가 만듭니다.
+// USE 는 풀의 연결 하나에만 적용되므로 DB 이름은 항상 DSN 으로 지정합니다.
+func OpenMySQL(cfg MySQLConfig) (*sql.DB, error) {
+	mc := mysql.NewConfig()
+	mc.User, mc.Passwd = cfg.User, cfg.Password
+	mc.Net, mc.Addr = "tcp", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	mc.ParseTime = true
+	// TIMESTAMP 컬럼(collected_at)을 UTC 로 읽고 UTC 인자와 비교하도록 세션 시간대를 UTC 로 고정
+	mc.Params = map[string]string{"time_zone": "'+00:00'"}
 
-	// DB 생성 및 테이블 설정 (모놀리딕 코드의 로직 이식)
-	_, _ = db.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s CHARACTER SET utf8mb4", config.DBName))
-	_, _ = db.Exec(fmt.Sprintf("USE %s", config.DBName))
+	if cfg.Create {
+		admin, err := sql.Open("mysql", mc.FormatDSN())
+		if err != nil {
+			return nil, err
+		}
+		_, err = admin.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s CHARACTER SET utf8mb4", QuoteIdent(cfg.Name)))
+		admin.Close()
+		if err != nil {
+			return nil, fmt.Errorf("데이터베이스 생성 실패: %w", err)
+		}
+	}
 
-	tableQuery := `
-    CREATE TABLE IF NOT EXISTS security_articles (
-        id INT AUTO_INCREMENT PRIMARY KEY,
-        title VARCHAR(512) NOT NULL,
-        link VARCHAR(1024) NOT NULL UNIQUE,
-        pubDate DATETIME NOT NULL,
-        description TEXT,
-        collected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-    ) ENGINE=InnoDB;`
+	mc.DBName = cfg.Name
+	return sql.Open("mysql", mc.FormatDSN())
+}
 
-	_, err = db.Exec(tableQuery)
-	return db, err
+// QuoteIdent 는 식별자를 백틱으로 감쌉니다. 이름 안의 백틱은 두 번 써서 이스케이프합니다.
+func QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
 }
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"database/sql"
+	"net/url"
+
+	_ "modernc.org/sqlite"
+)
+
+// OpenSQLite 는 path 의 SQLite 파일을 엽니다 (없으면 생성).
+// 시각은 UTC 문자열로 저장해 문자열 비교가 시간 순서와 같도록 합니다.
+func OpenSQLite(path string) (*sql.DB, error) {
+	q := url.Values{}
+	q.Add("_pragma", "busy_timeout(5000)")
+	q.Add("_pragma", "journal_mode(WAL)")
+	q.Set("_time_format", "sqlite")
+	db, err := sql.Open("sqlite", "file:"+path+"?"+q.Encode())
+	if err != nil {
+		return nil, err
+	}
+	// 단일 파일 DB 이므로 쓰기를 연결 하나로 직렬화
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
@@ -0,0 +1,145 @@
+// Package digest builds daily and weekly email digests of newly collected articles and sends them over SMTP.
+package digest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"jsn-modular/internal/severity"
+	"jsn-modular/internal/store"
+)
+
+// summaryLen 은 다이제스트에 넣는 기사 설명의 최대 글자 수입니다.
+const summaryLen = 200
+
+// Kind 는 다이제스트 주기입니다. 저장소의 구간 기록 키로도 씁니다.
+type Kind string
+
+const (
+	Daily  Kind = "daily"
+	Weekly Kind = "weekly"
+)
+
+// ParseKind 는 "daily", "weekly" 를 해석합니다.
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(s); k {
+	case Daily, Weekly:
+		return k, nil
+	}
+	return "", fmt.Errorf("알 수 없는 다이제스트 주기 %q (daily, weekly)", s)
+}
+
+// Title 은 메일 제목과 본문 머리에 쓰는 이름입니다.
+func (k Kind) Title() string {
+	if k == Weekly {
+		return "주간 보안 뉴스"
+	}
+	return "일간 보안 뉴스"
+}
+
+// boundary 는 now 이전의 마지막 구간 경계입니다. 일간은 현지 자정, 주간은 월요일 자정입니다.
+func (k Kind) boundary(now time.Time) time.Time {
+	y, m, d := now.Date()
+	t := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	if k == Weekly {
+		// 월요일 0, 일요일 6
+		t = t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	}
+	return t
+}
+
+// period 는 한 주기 전의 경계입니다. 서머타임이 있어도 달력 날짜로 셉니다.
+func (k Kind) period(end time.Time) time.Time {
+	if k == Weekly {
+		return end.AddDate(0, 0, -7)
+	}
+	return end.AddDate(0, 0, -1)
+}
+
+// Window 는 보낼 수집 시각 구간 [start, end) 입니다. end 는 now 이전의 마지막 경계입니다.
+// last 가 0 이면 직전 한 주기이고, 아니면 지난번 구간의 끝부터라 실행을 놓친 구간도 합쳐 보냅니다.
+// 이미 보낸 구간이면 ok 가 false 입니다.
+func Window(k Kind, last, now time.Time) (start, end time.Time, ok bool) {
+	end = k.boundary(now)
+	start = k.period(end)
+	if !last.IsZero() {
+		start = last.In(now.Location())
+	}
+	return start, end, start.Before(end)
+}
+
+// Data 는 다이제스트 템플릿에 넘기는 내용입니다. 시각은 모두 현지 시각입니다.
+type Data struct {
+	Kind    Kind
+	Title   string    // 일간/주간 보안 뉴스
+	Period  string    // 2006-01-02 또는 2006-01-02 ~ 2006-01-08 (구간의 마지막 날 포함)
+	Start   time.Time // 수집 시각 구간 시작 (포함)
+	End     time.Time // 수집 시각 구간 끝 (미포함)
+	Total   int
+	Sources []Source // 출처 이름 순
+}
+
+// Source 는 출처(피드) 하나의 신규 기사입니다.
+type Source struct {
+	Name     string
+	Count    int       // 구간의 전체 기사 수
+	Articles []Article // 최신순, 최대 digest.max_per_source 건
+	More     int       // 생략한 기사 수
+}
+
+// Article 은 다이제스트의 기사 한 줄입니다.
+type Article struct {
+	Title    string
+	Link     string
+	PubDate  time.Time
+	Severity string // low, medium, high, critical
+	Summary  string
+}
+
+// Build 는 구간의 기사를 출처별로 묶습니다. articles 는 store.Query 결과(최신순)입니다.
+func Build(k Kind, start, end time.Time, articles []store.Article, maxPerSource int) Data {
+	d := Data{Kind: k, Title: k.Title(), Start: start, End: end, Total: len(articles)}
+	first, last := start.Format(time.DateOnly), end.Add(-time.Nanosecond).Format(time.DateOnly)
+	d.Period = first
+	if first != last {
+		d.Period = first + " ~ " + last
+	}
+
+	bySource := make(map[string]*Source)
+	for _, a := range articles {
+		s := bySource[a.Source]
+		if s == nil {
+			s = &Source{Name: a.Source}
+			bySource[a.Source] = s
+		}
+		s.Count++
+		if maxPerSource > 0 && len(s.Articles) >= maxPerSource {
+			s.More++
+			continue
+		}
+		s.Articles = append(s.Articles, Article{
+			Title:    a.Title,
+			Link:     a.Link,
+			PubDate:  a.PubDate.In(start.Location()),
+			Severity: severity.Classify(a.Title, a.DescriptionText).String(),
+			Summary:  truncate(summaryLen, strings.Join(strings.Fields(a.DescriptionText), " ")),
+		})
+	}
+	for _, s := range bySource {
+		d.Sources = append(d.Sources, *s)
+	}
+	slices.SortFunc(d.Sources, func(a, b Source) int { return cmp.Compare(a.Name, b.Name) })
+	return d
+}
+
+// truncate 는 s 를 n 글자로 자르고 잘렸으면 "…" 를 붙입니다.
+func truncate(n int, s string) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,37 @@
+package digest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	kst := time.FixedZone("KST", 9*60*60)
+	at := func(m time.Month, d, h, min int) time.Time { return time.Date(2026, m, d, h, min, 0, 0, kst) }
+	// 2026-10-16 은 금요일, 10-19 는 월요일
+	tests := []struct {
+		name               string
+		kind               Kind
+		last, now          time.Time
+		wantStart, wantEnd time.Time
+		ok                 bool
+	}{
+		{"daily first run", Daily, time.Time{}, at(10, 16, 8, 0), at(10, 15, 0, 0), at(10, 16, 0, 0), true},
+		{"daily missed windows merged", Daily, at(10, 14, 0, 0), at(10, 16, 8, 0), at(10, 14, 0, 0), at(10, 16, 0, 0), true},
+		{"daily already sent", Daily, at(10, 16, 0, 0), at(10, 16, 23, 59), at(10, 16, 0, 0), at(10, 16, 0, 0), false},
+		{"daily last in UTC", Daily, time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC), at(10, 16, 8, 0), at(10, 15, 0, 0), at(10, 16, 0, 0), true},
+		{"weekly first run midweek", Weekly, time.Time{}, at(10, 16, 8, 0), at(10, 5, 0, 0), at(10, 12, 0, 0), true},
+		{"weekly Sunday night", Weekly, time.Time{}, at(10, 18, 23, 59), at(10, 5, 0, 0), at(10, 12, 0, 0), true},
+		{"weekly Monday midnight", Weekly, time.Time{}, at(10, 19, 0, 0), at(10, 12, 0, 0), at(10, 19, 0, 0), true},
+		{"weekly already sent", Weekly, at(10, 12, 0, 0), at(10, 18, 9, 0), at(10, 12, 0, 0), at(10, 12, 0, 0), false},
+		{"weekly missed week merged", Weekly, at(10, 5, 0, 0), at(10, 20, 9, 0), at(10, 5, 0, 0), at(10, 19, 0, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := Window(tt.kind, tt.last, tt.now)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) || ok != tt.ok {
+				t.Errorf("Window = [%v, %v) %v, want [%v, %v) %v", start, end, ok, tt.wantStart, tt.wantEnd, tt.ok)
+			}
+		})
+	}
+}
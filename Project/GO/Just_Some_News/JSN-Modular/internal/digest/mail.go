@@ -0,0 +1,173 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/config"
+)
+
+// Mail 은 보낼 메일 한 통입니다. 평문과 HTML 본문을 multipart/alternative 로 담습니다.
+type Mail struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+	Date    time.Time
+}
+
+// Bytes 는 RFC 5322 메시지를 만듭니다. 제목은 RFC 2047, 본문은 quoted-printable 로 인코딩합니다.
+func (m Mail) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("보내는 주소 형식 오류: %q", m.From)
+	}
+	to := make([]string, len(m.To))
+	for i, addr := range m.To {
+		a, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("받는 주소 형식 오류: %q", addr)
+		}
+		to[i] = a.String()
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+	header("From", from.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.BEncoding.Encode("utf-8", m.Subject))
+	header("Date", m.Date.Format(time.RFC1123Z))
+	header("Message-ID", messageID(from.Address))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `multipart/alternative; boundary="`+mw.Boundary()+`"`)
+	buf.WriteString("\r\n")
+
+	// 받는 쪽은 마지막 파트를 우선하므로 평문, HTML 순
+	for _, part := range []struct{ typ, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.typ},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// messageID 는 보내는 주소의 도메인으로 Message-ID 를 만듭니다.
+func messageID(from string) string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	_, domain, ok := strings.Cut(from, "@")
+	if !ok || domain == "" {
+		domain = "localhost"
+	}
+	return "<" + strconv.FormatInt(time.Now().Unix(), 10) + "." + hex.EncodeToString(b) + "@" + domain + ">"
+}
+
+// Send 는 SMTP 서버로 메일을 보냅니다. tls 가 starttls 면 STARTTLS 를 지원하지 않는 서버에는 보내지 않고,
+// 인증 정보가 있으면 PLAIN 인증을 씁니다 (암호화되지 않은 연결에서는 localhost 만 허용).
+func Send(ctx context.Context, cfg config.SMTP, m Mail) error {
+	if len(m.To) == 0 {
+		return errors.New("받는 주소가 없음")
+	}
+	msg, err := m.Bytes()
+	if err != nil {
+		return err
+	}
+	from, _ := mail.ParseAddress(m.From)
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	var conn net.Conn
+	if cfg.TLS == "tls" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: cfg.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("SMTP 연결 실패: %w", err)
+	}
+	// 제한 시간은 연결과 전송 전체에 적용하고, 종료 신호가 오면 연결을 끊음
+	_ = conn.SetDeadline(time.Now().Add(cfg.Timeout))
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP 연결 실패: %w", err)
+	}
+	defer c.Close()
+
+	if cfg.TLS == "starttls" {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP 서버가 STARTTLS 를 지원하지 않음 (로컬 시험 서버는 digest.smtp.tls: none)")
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return fmt.Errorf("SMTP STARTTLS 실패: %w", err)
+		}
+	}
+	if cfg.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("SMTP 서버가 인증(AUTH)을 지원하지 않음")
+		}
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("SMTP 인증 실패: %w", err)
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM 실패: %w", err)
+	}
+	for _, to := range m.To {
+		a, _ := mail.ParseAddress(to)
+		if err := c.Rcpt(a.Address); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s 실패: %w", a.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA 실패: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("SMTP 전송 실패: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP 전송 실패: %w", err)
+	}
+	// DATA 가 받아들여졌으면 보낸 것이므로 QUIT 실패는 무시 (구간을 기록하지 않으면 다시 보내게 됨)
+	_ = c.Quit()
+	return nil
+}
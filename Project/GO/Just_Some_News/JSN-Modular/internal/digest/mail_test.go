@@ -0,0 +1,187 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"jsn-modular/config"
+)
+
+// fakeSMTP 는 연결 하나를 받아 명령 줄과 DATA 본문을 기록하는 시험용 SMTP 서버입니다.
+type fakeSMTP struct {
+	ln       net.Listener
+	commands []string
+	data     []byte
+	done     chan error
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{ln: ln, done: make(chan error, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go func() { s.done <- s.serve() }()
+	return s
+}
+
+func (s *fakeSMTP) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+func (s *fakeSMTP) serve() error {
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	tp := textproto.NewConn(conn)
+
+	if err := tp.PrintfLine("220 localhost ESMTP fake"); err != nil {
+		return err
+	}
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return err
+		}
+		s.commands = append(s.commands, line)
+		verb, _, _ := strings.Cut(strings.ToUpper(line), " ")
+		switch verb {
+		case "EHLO":
+			err = tp.PrintfLine("250-localhost\r\n250 8BITMIME")
+		case "MAIL", "RCPT":
+			err = tp.PrintfLine("250 OK")
+		case "DATA":
+			if err = tp.PrintfLine("354 end with ."); err != nil {
+				return err
+			}
+			if s.data, err = tp.ReadDotBytes(); err != nil {
+				return err
+			}
+			err = tp.PrintfLine("250 queued")
+		case "QUIT":
+			return tp.PrintfLine("221 bye")
+		default:
+			err = tp.PrintfLine("502 not implemented")
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	srv := startFakeSMTP(t)
+	cfg := config.SMTP{Host: "127.0.0.1", Port: srv.port(), TLS: "none", Timeout: 5 * time.Second}
+	m := Mail{
+		From:    "JSN <jsn@example.com>",
+		To:      []string{"보안팀 <sec@example.com>", "ops@example.com"},
+		Subject: "[JSN] 일간 보안 뉴스 2026-10-16 (3건)",
+		Text:    "랜섬웨어 조직 검거\nhttps://example.com/a",
+		HTML:    "<p>랜섬웨어 조직 검거</p>",
+		Date:    time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+	}
+	if err := Send(context.Background(), cfg, m); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := <-srv.done; err != nil {
+		t.Fatalf("시험 서버: %v", err)
+	}
+
+	var envelope []string
+	for _, c := range srv.commands {
+		if strings.HasPrefix(c, "MAIL") || strings.HasPrefix(c, "RCPT") {
+			envelope = append(envelope, c)
+		}
+	}
+	want := []string{"MAIL FROM:<jsn@example.com> BODY=8BITMIME", "RCPT TO:<sec@example.com>", "RCPT TO:<ops@example.com>"}
+	if strings.Join(envelope, "\n") != strings.Join(want, "\n") {
+		t.Errorf("봉투 명령 = %q, want %q", envelope, want)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(srv.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := msg.Header.Get("Subject")
+	if !strings.HasPrefix(raw, "=?utf-8?b?") {
+		t.Errorf("제목이 RFC 2047 인코딩되지 않음: %q", raw)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(raw)
+	if err != nil || subject != m.Subject {
+		t.Errorf("제목 = %q, %v, want %q", subject, err, m.Subject)
+	}
+	if to := msg.Header.Get("To"); !strings.Contains(to, "<sec@example.com>") || !strings.Contains(to, "<ops@example.com>") {
+		t.Errorf("To = %q", to)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	parts := []struct{ typ, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	}
+	for _, want := range parts {
+		p, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("%s 파트 없음: %v", want.typ, err)
+		}
+		// multipart.Reader 가 quoted-printable 을 풀어 줌
+		body, err := io.ReadAll(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.Header.Get("Content-Type"); got != want.typ {
+			t.Errorf("파트 Content-Type = %q, want %q", got, want.typ)
+		}
+		if string(body) != want.body {
+			t.Errorf("%s 본문 = %q, want %q", want.typ, body, want.body)
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("파트가 2개보다 많음: %v", err)
+	}
+}
+
+func TestSendRequiresSTARTTLS(t *testing.T) {
+	srv := startFakeSMTP(t)
+	cfg := config.SMTP{Host: "127.0.0.1", Port: srv.port(), TLS: "starttls", Timeout: 5 * time.Second}
+	err := Send(context.Background(), cfg, Mail{From: "jsn@example.com", To: []string{"sec@example.com"}, Subject: "t"})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("STARTTLS 없는 서버 오류 = %v", err)
+	}
+	<-srv.done // 클라이언트가 연결을 닫으면 끝남
+	for _, c := range srv.commands {
+		if strings.HasPrefix(c, "MAIL") {
+			t.Errorf("STARTTLS 없이 메일을 보냄: %q", c)
+		}
+	}
+}
+
+func TestMailBytesRejectsBadAddress(t *testing.T) {
+	for _, m := range []Mail{
+		{From: "not an address", To: []string{"sec@example.com"}},
+		{From: "jsn@example.com", To: []string{"sec@"}},
+	} {
+		if _, err := m.Bytes(); err == nil {
+			t.Errorf("Bytes(%+v) 에 오류가 없음", m)
+		}
+	}
+	if err := Send(context.Background(), config.SMTP{Host: "127.0.0.1", Port: 1}, Mail{From: "jsn@example.com"}); err == nil {
+		t.Error("받는 주소 없이 Send 가 성공함")
+	}
+}
@@ -0,0 +1,143 @@
+package digest
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// 내장 본문 템플릿. digest.html_template, digest.text_template 파일로 바꿀 수 있으며 같은 Data 와 함수를 씁니다.
+const (
+	defaultText = `{{.Title}} {{.Period}}
+신규 기사 {{.Total}}건
+{{range .Sources}}
+■ {{.Name}} ({{.Count}}건)
+{{- range .Articles}}
+- {{if ne .Severity "low"}}[{{upper .Severity}}] {{end}}{{.Title}}
+  {{.PubDate.Format "01-02 15:04"}} {{.Link}}
+{{- end}}
+{{- if .More}}
+  … 외 {{.More}}건
+{{- end}}
+{{else}}
+이 기간에 수집된 신규 기사가 없습니다.
+{{end}}
+--
+Just Some News (jsn digest)
+`
+
+	defaultHTML = `<!DOCTYPE html>
+<html lang="ko">
+<head><meta charset="utf-8"><title>{{.Title}} {{.Period}}</title></head>
+<body style="margin:0;padding:24px;background:#f4f4f5;color:#222;font-family:'Apple SD Gothic Neo','Malgun Gothic',sans-serif;">
+<div style="max-width:720px;margin:0 auto;padding:24px;background:#fff;border-radius:6px;">
+<h1 style="margin:0 0 4px;font-size:20px;">{{.Title}}</h1>
+<p style="margin:0 0 16px;color:#666;">{{.Period}} · 신규 기사 {{.Total}}건</p>
+{{- range .Sources}}
+<h2 style="margin:24px 0 8px;padding-bottom:4px;border-bottom:2px solid #333;font-size:16px;">{{.Name}} <span style="color:#888;font-weight:normal;">{{.Count}}건</span></h2>
+<ul style="margin:0;padding-left:18px;">
+{{- range .Articles}}
+<li style="margin:0 0 10px;">
+{{- if ne .Severity "low"}}<span style="{{badge .Severity}}">{{upper .Severity}}</span> {{end -}}
+<a href="{{.Link}}" style="color:#1a0dab;text-decoration:none;">{{.Title}}</a>
+<span style="color:#888;font-size:12px;">{{.PubDate.Format "01-02 15:04"}}</span>
+{{- with .Summary}}
+<div style="color:#555;font-size:13px;">{{.}}</div>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+{{- if .More}}
+<p style="margin:0;color:#888;font-size:13px;">외 {{.More}}건</p>
+{{- end}}
+{{- else}}
+<p>이 기간에 수집된 신규 기사가 없습니다.</p>
+{{- end}}
+<p style="margin:24px 0 0;color:#aaa;font-size:12px;">Just Some News (jsn digest)</p>
+</div>
+</body>
+</html>
+`
+)
+
+var textFuncs = template.FuncMap{
+	"upper":    strings.ToUpper,
+	"truncate": truncate,
+}
+
+var htmlFuncs = htmltemplate.FuncMap{
+	"upper":    strings.ToUpper,
+	"truncate": truncate,
+	// badge 는 심각도 표시의 인라인 스타일입니다.
+	"badge": func(severity string) htmltemplate.CSS {
+		bg := map[string]string{"medium": "#f1c40f", "high": "#e67e22", "critical": "#e74c3c"}[severity]
+		return htmltemplate.CSS("padding:1px 6px;border-radius:3px;background:" + bg + ";color:#fff;font-size:11px;font-weight:bold;")
+	},
+}
+
+// Renderer 는 제목과 평문/HTML 본문 템플릿입니다.
+type Renderer struct {
+	subject *template.Template
+	text    *template.Template
+	html    *htmltemplate.Template
+}
+
+// NewRenderer 는 제목 템플릿과 본문 템플릿 파일을 읽습니다. 파일 경로가 비어 있으면 내장 템플릿을 씁니다.
+func NewRenderer(subject, textFile, htmlFile string) (*Renderer, error) {
+	var r Renderer
+	var err error
+	if r.subject, err = template.New("subject").Funcs(textFuncs).Parse(subject); err != nil {
+		return nil, fmt.Errorf("다이제스트 제목 템플릿 오류: %w", err)
+	}
+
+	text, err := readTemplate(textFile, defaultText)
+	if err != nil {
+		return nil, err
+	}
+	if r.text, err = template.New("text").Funcs(textFuncs).Parse(text); err != nil {
+		return nil, fmt.Errorf("다이제스트 평문 템플릿 오류: %w", err)
+	}
+
+	html, err := readTemplate(htmlFile, defaultHTML)
+	if err != nil {
+		return nil, err
+	}
+	if r.html, err = htmltemplate.New("html").Funcs(htmlFuncs).Parse(html); err != nil {
+		return nil, fmt.Errorf("다이제스트 HTML 템플릿 오류: %w", err)
+	}
+	return &r, nil
+}
+
+func readTemplate(path, def string) (string, error) {
+	if path == "" {
+		return def, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("다이제스트 템플릿 읽기 실패: %w", err)
+	}
+	return string(b), nil
+}
+
+// Render 는 제목과 평문, HTML 본문을 만듭니다. 제목의 줄바꿈은 공백으로 바꿉니다.
+func (r *Renderer) Render(d Data) (subject, text, html string, err error) {
+	var b strings.Builder
+	if err := r.subject.Execute(&b, d); err != nil {
+		return "", "", "", fmt.Errorf("다이제스트 제목 생성 실패: %w", err)
+	}
+	subject = strings.Join(strings.Fields(b.String()), " ")
+
+	b.Reset()
+	if err := r.text.Execute(&b, d); err != nil {
+		return "", "", "", fmt.Errorf("다이제스트 평문 생성 실패: %w", err)
+	}
+	text = b.String()
+
+	b.Reset()
+	if err := r.html.Execute(&b, d); err != nil {
+		return "", "", "", fmt.Errorf("다이제스트 HTML 생성 실패: %w", err)
+	}
+	return subject, text, b.String(), nil
+}
@@ -0,0 +1,27 @@
+package rss
+
+import (
+	"context"
+	"log"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/alert"
+	"jsn-modular/internal/store"
+)
+
+// notifyAlerts 는 신규 기사에 관심 키워드 규칙을 적용해 웹훅으로 알립니다.
+// 알림 실패는 수집 결과(종료 코드)에 반영하지 않고 다음 수집에서 다시 보냅니다.
+func notifyAlerts(ctx context.Context, st store.Store, cfg config.Alerts, articles []store.Article) {
+	n, err := alert.New(cfg)
+	if err != nil {
+		log.Printf(">>> 알림 설정 오류, 알림을 보내지 않음: %v", err)
+		return
+	}
+	if !n.Enabled() {
+		return
+	}
+	res := n.Notify(ctx, st, articles)
+	if res.Sent+res.Failed+res.Skipped > 0 {
+		log.Printf(">>> 알림 완료: 발송 %d건 / 실패 %d건 / 이미 보냄 %d건", res.Sent, res.Failed, res.Skipped)
+	}
+}
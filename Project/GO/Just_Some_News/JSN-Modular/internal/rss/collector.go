@@ -2,85 +2,132 @@
 package rss
 
 import (
-	"database/sql"
-	"encoding/xml"
+	"context"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"strings"
 	"time"
 
-	"jsn-modular/internal/config"
-
-	"golang.org/x/text/encoding/korean"
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
 )
 
-// RSS 는 보안 뉴스 수집을 위한 XML 매핑 구조체입니다.
-type RSS struct {
-	XMLName xml.Name `xml:"rss"`
-	Channel Channel  `xml:"channel"`
-}
-
-type Channel struct {
-	Items []Item `xml:"item"`
+// feedStats 는 피드 하나의 저장 결과입니다.
+type feedStats struct {
+	New       int
+	Updated   int
+	Unchanged int
+	Scanned   int
+	Pending   []pendingArticle // 본문 추출 대상 신규 기사 (Feed.FullText)
+	Inserted  []store.Article  // 새로 삽입된 기사 (관심 키워드 알림 대상)
 }
 
-// Item 은 개별 뉴스 기사 정보를 담는 구조체입니다.
-type Item struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
-}
-
-// Collect fetches and stores RSS feed items into the database.
-func Collect(db *sql.DB) {
+// Collect fetches every enabled feed in cfg.Feeds and stores new items into st.
+// 피드는 워커 풀에서 동시에 가져오고, 저장소 쓰기는 이 함수(단일 writer)에서만 수행합니다.
+// 피드별 성공/실패와 원인은 반환값에 담기며, 호출자가 종료 코드 등으로 바꿉니다.
+func Collect(ctx context.Context, st store.Store, cfg *config.Config) RunResult {
 	log.Println(">>> 뉴스 수집 시작...")
+	run := RunResult{Started: time.Now()}
 
-	resp, err := http.Get(config.RSSURL)
-	if err != nil {
-		log.Printf("RSS 요청 실패: %v", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	decoder := xml.NewDecoder(resp.Body)
-	// EUC-KR 처리 핸들러 등록
-	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
-		if strings.ToLower(charset) == "euc-kr" {
-			return korean.EUCKR.NewDecoder().Reader(input), nil
+	var jobs []fetchJob
+	skippedCnt := 0
+	for _, feed := range cfg.Feeds {
+		if !feed.Enabled {
+			log.Printf(">>> [%s] 비활성 피드, 건너뜀", feed.Name)
+			continue
+		}
+		state, err := st.LoadFeedState(ctx, feed.Name, feed.URL)
+		if err != nil {
+			log.Printf(">>> [%s] 피드 상태 조회 실패, 전체 요청으로 진행: %v", feed.Name, err)
 		}
-		return nil, fmt.Errorf("unsupported charset: %s", charset)
-	}
 
-	var feed RSS
-	if err := decoder.Decode(&feed); err != nil {
-		log.Printf("XML 파싱 실패: %v", err)
-		return
+		// 서킷 브레이커: 연속 실패 피드는 쿨다운 동안 건너뛰고, 이후 한 번만 프로브
+		probe := false
+		if state.Failures >= cfg.Retry.BreakerThreshold {
+			if breakerOpen(state, cfg.Retry.BreakerThreshold, cfg.Retry.BreakerCooldown) {
+				retryAt := state.LastFailure.Add(cfg.Retry.BreakerCooldown).Local()
+				log.Printf(">>> [%s] 서킷 오픈: 연속 %d회 실패, %s 이후 재시도", feed.Name, state.Failures, retryAt.Format(time.DateTime))
+				skippedCnt++
+				run.Feeds = append(run.Feeds, FeedResult{
+					Feed:   feed.Name,
+					Status: FeedCircuitOpen,
+					Err:    fmt.Errorf("서킷 오픈: 연속 %d회 실패, %s 이후 재시도", state.Failures, retryAt.Format(time.DateTime)),
+				})
+				continue
+			}
+			probe = true
+			log.Printf(">>> [%s] 서킷 쿨다운 경과, 프로브 요청", feed.Name)
+		}
+		jobs = append(jobs, fetchJob{Feed: feed, State: state, Probe: probe})
 	}
 
-	newCnt := 0
-	for _, item := range feed.Channel.Items {
-		var exists bool
-		query := "SELECT EXISTS(SELECT 1 FROM security_articles WHERE link = ?)"
-		_ = db.QueryRow(query, item.Link).Scan(&exists)
-
-		if !exists {
-			t, err := time.Parse(time.RFC3339, item.Date)
-			if err != nil {
-				t = time.Now()
+	limiter := newHostLimiter(cfg.Fetch.HostMaxConcurrent, cfg.Fetch.HostMinInterval)
+	totalNew, totalUpdated, notModifiedCnt, failedCnt, storeFailedCnt := 0, 0, 0, 0, 0
+	var pending []pendingArticle
+	var inserted []store.Article
+	for res := range fetchAll(ctx, jobs, cfg.Fetch.Workers, limiter, cfg.Retry) {
+		feed := res.Feed
+		if ctx.Err() != nil {
+			// 종료 요청으로 취소된 실행은 피드 실패로 기록하지 않음
+			log.Printf(">>> [%s] 수집 취소됨", feed.Name)
+			run.Feeds = append(run.Feeds, FeedResult{Feed: feed.Name, Status: FeedCanceled})
+			continue
+		}
+		if res.Err != nil {
+			failedCnt++
+			log.Printf(">>> [%s] 수집 실패: %v", feed.Name, res.Err)
+			run.Feeds = append(run.Feeds, FeedResult{Feed: feed.Name, Status: FeedFailed, Err: res.Err})
+			if err := st.RecordFeedFailure(ctx, feed.Name, feed.URL, res.Err); err != nil {
+				log.Printf(">>> [%s] 피드 상태 저장 실패: %v", feed.Name, err)
 			}
-
-			_, err = db.Exec(
-				"INSERT INTO security_articles (title, link, pubDate, description) VALUES (?, ?, ?, ?)",
-				item.Title, item.Link, t, item.Description,
-			)
-			if err == nil {
-				newCnt++
-				log.Printf(">>> 신규 수집: %s", item.Title)
+			continue
+		}
+		if res.NotModified {
+			notModifiedCnt++
+			log.Printf(">>> [%s] 변경 없음 (304 Not Modified)", feed.Name)
+			run.Feeds = append(run.Feeds, FeedResult{Feed: feed.Name, Status: FeedNotModified})
+			if err := st.MarkFeedAlive(ctx, feed.Name); err != nil {
+				log.Printf(">>> [%s] 피드 상태 저장 실패: %v", feed.Name, err)
 			}
+			continue
+		}
+		log.Printf(">>> [%s] 피드 포맷: %s", feed.Name, res.Format)
+
+		stats, err := upsertArticles(ctx, st, feed, buildRows(feed, res.Entries))
+		if err != nil {
+			// 저장 실패는 롤백되었으므로 검증자를 갱신하지 않아 다음 실행에서 다시 받음
+			storeFailedCnt++
+			log.Printf(">>> [%s] 저장 실패 (롤백): %v", feed.Name, err)
+			run.Feeds = append(run.Feeds, FeedResult{Feed: feed.Name, Status: FeedStoreFailed, Err: fmt.Errorf("저장 실패: %w", err)})
+			continue
+		}
+		// 저장까지 성공한 경우에만 검증자를 갱신
+		if err := st.SaveFeedState(ctx, feed.Name, feed.URL, res.Header.Get("ETag"), res.Header.Get("Last-Modified")); err != nil {
+			log.Printf(">>> [%s] 피드 상태 저장 실패: %v", feed.Name, err)
 		}
+		totalNew += stats.New
+		totalUpdated += stats.Updated
+		run.Feeds = append(run.Feeds, FeedResult{
+			Feed: feed.Name, Status: FeedOK, New: stats.New, Updated: stats.Updated, Unchanged: stats.Unchanged,
+		})
+		pending = append(pending, stats.Pending...)
+		inserted = append(inserted, stats.Inserted...)
+		log.Printf(">>> [%s] 수집 완료: 신규 %d건 / 갱신 %d건 / 변경 없음 %d건 / 전체 %d건 스캔",
+			feed.Name, stats.New, stats.Updated, stats.Unchanged, stats.Scanned)
 	}
-	log.Printf(">>> 수집 완료: 신규 %d건 / 전체 %d건 스캔", newCnt, len(feed.Channel.Items))
+	log.Printf(">>> 수집 완료: 피드 %d개 / 변경 없음 %d개 / 실패 %d개 / 저장 실패 %d개 / 서킷 오픈 %d개 / 신규 %d건 / 갱신 %d건",
+		len(jobs), notModifiedCnt, failedCnt, storeFailedCnt, skippedCnt, totalNew, totalUpdated)
+
+	// 알림은 본문 추출보다 먼저 보냄. 신규 기사가 없어도 이전에 실패한 알림은 다시 보냄
+	if ctx.Err() == nil {
+		notifyAlerts(ctx, st, cfg.Alerts, inserted)
+	}
+
+	if len(pending) > 0 && ctx.Err() == nil {
+		enrichArticles(ctx, st, pending, limiter, cfg.Content.Workers)
+	}
+
+	run.New, run.Updated = totalNew, totalUpdated
+	run.Canceled = ctx.Err() != nil
+	run.Finished = time.Now()
+	return run
 }
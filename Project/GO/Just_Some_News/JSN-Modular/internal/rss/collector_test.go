@@ -0,0 +1,93 @@
+package rss
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jsn-modular/internal/store"
+)
+
+func TestBreakerOpen(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		state store.FeedState
+		want  bool
+	}{
+		{"below threshold", store.FeedState{Failures: 2, LastFailure: now}, false},
+		{"open", store.FeedState{Failures: 3, LastFailure: now}, true},
+		{"cooldown elapsed", store.FeedState{Failures: 7, LastFailure: now.Add(-2 * time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		if got := breakerOpen(tt.state, 3, time.Hour); got != tt.want {
+			t.Errorf("%s: breakerOpen = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCollectCircuitBreaker(t *testing.T) {
+	var hits atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testFeedBody))
+	}))
+	defer srv.Close()
+
+	cfg := testConfig(srv.URL)
+	cfg.Retry.BreakerThreshold = 2
+	cfg.Retry.BreakerCooldown = time.Hour
+	st := store.NewMemory(store.Options{})
+	ctx := context.Background()
+	failures := func() int {
+		state, _ := st.LoadFeedState(ctx, "test", srv.URL)
+		return state.Failures
+	}
+
+	// 연속 실패가 임계치에 도달할 때까지는 재시도까지 요청
+	for i := 1; i <= 2; i++ {
+		run := Collect(ctx, st, cfg)
+		if run.Feeds[0].Status != FeedFailed || failures() != i {
+			t.Fatalf("%d번째 수집 = %s, 연속 실패 %d", i, run.Feeds[0].Status, failures())
+		}
+	}
+	if n := hits.Load(); n != 2*int32(cfg.Retry.MaxAttempts) {
+		t.Errorf("요청 %d회, want %d", n, 2*cfg.Retry.MaxAttempts)
+	}
+
+	// 쿨다운 동안에는 요청하지 않고 실패로 보고
+	hits.Store(0)
+	run := Collect(ctx, st, cfg)
+	if run.Feeds[0].Status != FeedCircuitOpen || !run.AllFailed() || hits.Load() != 0 {
+		t.Fatalf("서킷 오픈 수집 = %s, 요청 %d회", run.Feeds[0].Status, hits.Load())
+	}
+	if failures() != 2 {
+		t.Errorf("건너뛴 실행이 실패로 기록됨: 연속 실패 %d", failures())
+	}
+
+	// 쿨다운이 지나면 재시도 없이 프로브 한 번. 실패하면 다시 열림
+	cfg.Retry.BreakerCooldown = time.Nanosecond
+	run = Collect(ctx, st, cfg)
+	if run.Feeds[0].Status != FeedFailed || hits.Load() != 1 || failures() != 3 {
+		t.Errorf("실패한 프로브 = %s, 요청 %d회, 연속 실패 %d", run.Feeds[0].Status, hits.Load(), failures())
+	}
+	if run.Err() == nil {
+		t.Error("실패한 프로브의 실행 오류가 없음")
+	}
+
+	// 프로브가 성공하면 닫힘
+	healthy.Store(true)
+	hits.Store(0)
+	run = Collect(ctx, st, cfg)
+	if run.Feeds[0].Status != FeedOK || hits.Load() != 1 || failures() != 0 {
+		t.Errorf("성공한 프로브 = %s, 요청 %d회, 연속 실패 %d", run.Feeds[0].Status, hits.Load(), failures())
+	}
+}
@@ -0,0 +1,121 @@
+package rss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultZone 은 시간대 표기가 없는 날짜에 적용하는 시간대입니다 (국내 언론사 기준 KST).
+var defaultZone = time.FixedZone("KST", 9*60*60)
+
+// zonedLayouts 는 숫자 시간대 오프셋을 포함한 레이아웃입니다.
+// 이름 시간대(KST, GMT 등)는 normalizeZone 에서 숫자 오프셋으로 바꾼 뒤 이 목록으로 해석합니다.
+var zonedLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"02 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC822Z,
+	"Mon, 02 Jan 06 15:04:05 -0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05-07:00",
+	time.RubyDate,
+}
+
+// localLayouts 는 시간대 표기가 없는 레이아웃으로, defaultZone 기준으로 해석합니다.
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006.01.02 15:04:05",
+	"2006.01.02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"Mon, 02 Jan 2006 15:04:05",
+	"Mon, 2 Jan 2006 15:04:05",
+	"02 Jan 2006 15:04:05",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+}
+
+// namedZones 는 RFC 822 및 국내외 피드에서 쓰이는 이름 시간대의 UTC 오프셋(초)입니다.
+// time.Parse 는 모르는 약어를 오프셋 0 으로 처리하므로 직접 변환합니다.
+var namedZones = map[string]int{
+	"UT": 0, "UTC": 0, "GMT": 0, "Z": 0,
+	"KST": 9 * 3600, "JST": 9 * 3600,
+	"EST": -5 * 3600, "EDT": -4 * 3600,
+	"CST": -6 * 3600, "CDT": -5 * 3600,
+	"MST": -7 * 3600, "MDT": -6 * 3600,
+	"PST": -8 * 3600, "PDT": -7 * 3600,
+	"CET": 1 * 3600, "CEST": 2 * 3600,
+}
+
+// ParseDate 는 알려진 레이아웃을 차례로 시도해 날짜 문자열을 UTC 시각으로 변환합니다.
+func ParseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, " ("); i > 0 {
+		// "+0900 (KST)" 처럼 뒤에 붙은 주석 제거
+		s = strings.TrimSpace(s[:i])
+	}
+	if s == "" {
+		return time.Time{}, false
+	}
+	s, ok := normalizeZone(s)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, s, defaultZone); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// normalizeZone 은 끝에 붙은 이름 시간대를 숫자 오프셋으로 바꿉니다.
+// 알 수 없는 이름 시간대이면 false 를 반환합니다.
+func normalizeZone(s string) (string, bool) {
+	i := strings.LastIndexByte(s, ' ')
+	if i < 0 {
+		return s, true
+	}
+	zone := s[i+1:]
+	for _, r := range zone {
+		if r < 'A' || r > 'Z' {
+			// 숫자 오프셋이거나 시간대 표기가 아님
+			return s, true
+		}
+	}
+	offset, ok := namedZones[zone]
+	if !ok {
+		return s, false
+	}
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%s %c%02d%02d", s[:i], sign, offset/3600, offset%3600/60), true
+}
+
+// resolveDate 는 Published, Date(dc:date), Updated 순으로 기사 날짜를 해석합니다.
+// 모두 실패하면 fallback 을 UTC 로 반환하고 guessed 를 true 로 표시합니다.
+func resolveDate(e Entry, fallback time.Time) (t time.Time, guessed bool) {
+	for _, candidate := range []string{e.Published, e.Date, e.Updated} {
+		if t, ok := ParseDate(candidate); ok {
+			return t, false
+		}
+	}
+	return fallback.UTC(), true
+}
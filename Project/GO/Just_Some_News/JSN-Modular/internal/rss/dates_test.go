@@ -0,0 +1,84 @@
+package rss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// 2026-10-16 09:00 KST
+	kst9 := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in     string
+		want   time.Time
+		wantOK bool
+	}{
+		{"Fri, 16 Oct 2026 09:00:00 +0900", kst9, true},
+		{"Fri, 16 Oct 2026 09:00:00 KST", kst9, true},
+		{"Fri, 16 Oct 2026 00:00:00 GMT", kst9, true},
+		{"Thu, 15 Oct 2026 20:00:00 EDT", kst9, true},
+		{"Fri, 16 Oct 2026 09:00:00 +0900 (KST)", kst9, true},
+		{"Fri, 16 Oct 2026 09:00 +0900", kst9, true},
+		{"16 Oct 2026 09:00:00 +0900", kst9, true},
+		{"2026-10-16T09:00:00+09:00", kst9, true},
+		{"2026-10-16T00:00:00Z", kst9, true},
+		{"2026-10-16T09:00:00+0900", kst9, true},
+		{"2026-10-16 09:00:00 +0900", kst9, true},
+		// 시간대 표기가 없으면 KST
+		{"2026-10-16 09:00:00", kst9, true},
+		{"2026-10-16T09:00:00", kst9, true},
+		{"2026.10.16 09:00", kst9, true},
+		{"2026/10/16 09:00:00", kst9, true},
+		{"Fri, 16 Oct 2026 09:00:00", kst9, true},
+		{" 2026-10-16 ", kst9.Add(-9 * time.Hour), true},
+		// 해석할 수 없는 날짜
+		{"", time.Time{}, false},
+		{"어제", time.Time{}, false},
+		{"Fri, 16 Oct 2026 09:00:00 XYZ", time.Time{}, false},
+		{"2026-13-01", time.Time{}, false},
+		{"2026-02-30 09:00", time.Time{}, false},
+		{"16/10/2026", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDate(tt.in)
+		if ok != tt.wantOK {
+			t.Errorf("ParseDate(%q) ok = %v, want %v", tt.in, ok, tt.wantOK)
+			continue
+		}
+		if !got.Equal(tt.want) || (ok && got.Location() != time.UTC) {
+			t.Errorf("ParseDate(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeZone(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantOK   bool
+	}{
+		{"Fri, 16 Oct 2026 09:00:00 KST", "Fri, 16 Oct 2026 09:00:00 +0900", true},
+		{"Fri, 16 Oct 2026 09:00:00 PST", "Fri, 16 Oct 2026 09:00:00 -0800", true},
+		{"Fri, 16 Oct 2026 09:00:00 UT", "Fri, 16 Oct 2026 09:00:00 +0000", true},
+		{"Fri, 16 Oct 2026 09:00:00 +0900", "Fri, 16 Oct 2026 09:00:00 +0900", true},
+		{"2026-10-16T09:00:00Z", "2026-10-16T09:00:00Z", true},
+		{"Fri, 16 Oct 2026 09:00:00 XYZ", "Fri, 16 Oct 2026 09:00:00 XYZ", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeZone(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("normalizeZone(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestResolveDate(t *testing.T) {
+	fallback := time.Date(2026, 10, 16, 12, 0, 0, 0, defaultZone)
+	got, guessed := resolveDate(Entry{Published: "언젠가", Date: "2026-10-16T00:00:00Z", Updated: "2026-10-17T00:00:00Z"}, fallback)
+	if guessed || !got.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("resolveDate = %v, %v, want dc:date", got, guessed)
+	}
+	got, guessed = resolveDate(Entry{Published: "언젠가"}, fallback)
+	if !guessed || !got.Equal(fallback) || got.Location() != time.UTC {
+		t.Errorf("resolveDate = %v, %v, want 수집 시각 (UTC)", got, guessed)
+	}
+}
@@ -0,0 +1,71 @@
+package rss
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"jsn-modular/internal/content"
+	"jsn-modular/internal/store"
+)
+
+// pendingArticle 은 본문 추출을 기다리는 신규 기사입니다.
+type pendingArticle struct {
+	ID   int64
+	Feed string
+	Link string
+}
+
+type enrichResult struct {
+	Article pendingArticle
+	content.Result
+}
+
+// enrichArticles 는 신규 기사의 원문 페이지에서 본문을 추출해 content 컬럼을 채웁니다.
+// 페이지 요청은 워커 풀에서 호스트 제한을 지키며 수행하고, UPDATE 는 이 함수에서만 실행합니다.
+func enrichArticles(ctx context.Context, st store.ArticleStore, pending []pendingArticle, limiter *hostLimiter, workers int) {
+	log.Printf(">>> 본문 추출 시작: %d건", len(pending))
+
+	queue := make(chan pendingArticle)
+	results := make(chan enrichResult)
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Go(func() {
+			for a := range queue {
+				release, err := limiter.acquire(ctx, hostOf(a.Link))
+				if err != nil {
+					// 종료 중이면 본문 상태를 남기지 않고 건너뜀
+					continue
+				}
+				res := content.Fetch(ctx, a.Link)
+				release()
+				results <- enrichResult{Article: a, Result: res}
+			}
+		})
+	}
+	go func() {
+		for _, a := range pending {
+			queue <- a
+		}
+		close(queue)
+		wg.Wait()
+		close(results)
+	}()
+
+	okCnt := 0
+	for r := range results {
+		if r.Err != nil {
+			log.Printf(">>> [%s] 본문 추출 실패 (%s): %s: %v", r.Article.Feed, r.Status, r.Article.Link, r.Err)
+		}
+		err := st.UpdateContent(ctx, r.Article.ID, store.Content{Text: r.Text, WordCount: r.WordCount, Status: r.Status})
+		if err != nil {
+			log.Printf(">>> [%s] 본문 저장 실패: %v", r.Article.Feed, err)
+			continue
+		}
+		if r.Status == content.StatusOK {
+			okCnt++
+		}
+	}
+	log.Printf(">>> 본문 추출 완료: 성공 %d건 / 대상 %d건", okCnt, len(pending))
+}
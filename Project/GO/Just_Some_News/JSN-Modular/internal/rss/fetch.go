@@ -0,0 +1,105 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/backoff"
+	"jsn-modular/internal/store"
+)
+
+// httpClient 는 피드 요청에 사용하는 공용 클라이언트입니다.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// fetchResult 는 워커가 가져와 파싱한 피드 하나의 결과입니다. DB 에는 접근하지 않습니다.
+type fetchResult struct {
+	Feed        config.Feed
+	Format      Format
+	Entries     []Entry
+	Header      http.Header
+	NotModified bool // 304 응답으로 본문을 받지 않음
+	Err         error
+}
+
+// fetchFeed 는 조건부 GET 으로 피드를 가져와 파싱합니다. 304 이면 파싱을 생략합니다.
+// 네트워크 오류와 5xx/429 응답은 지터가 적용된 지수 백오프로 최대 attempts 회까지 시도하며,
+// Retry-After 헤더가 있으면 그 값을 따릅니다.
+// ctx 가 취소되면 진행 중인 요청과 재시도 대기를 중단합니다.
+func fetchFeed(ctx context.Context, feed config.Feed, state store.FeedState, attempts int, retry config.Retry) fetchResult {
+	var res fetchResult
+	for attempt := 1; ; attempt++ {
+		var retryAfter time.Duration
+		var retryable bool
+		res, retryAfter, retryable = fetchOnce(ctx, feed, state)
+		if res.Err == nil || !retryable || attempt >= attempts || ctx.Err() != nil {
+			return res
+		}
+
+		delay := backoff.Delay(attempt, retry.BaseDelay, retry.MaxDelay)
+		if retryAfter > 0 {
+			if retryAfter > retry.MaxDelay {
+				// 서버가 요구한 대기 시간이 상한을 넘으면 이번 실행에서는 포기
+				res.Err = fmt.Errorf("%w (Retry-After %s 초과, 재시도 중단)", res.Err, retryAfter)
+				return res
+			}
+			delay = retryAfter
+		}
+		log.Printf(">>> [%s] 요청 실패 (%d/%d회), %s 후 재시도: %v", feed.Name, attempt, attempts, delay.Round(time.Millisecond), res.Err)
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			res.Err = fmt.Errorf("%w (재시도 대기 중 취소)", res.Err)
+			return res
+		case <-t.C:
+		}
+	}
+}
+
+// fetchOnce 는 요청을 한 번 보냅니다. 재시도 가능 여부와 Retry-After 대기 시간을 함께 반환합니다.
+func fetchOnce(ctx context.Context, feed config.Feed, state store.FeedState) (res fetchResult, retryAfter time.Duration, retryable bool) {
+	res.Feed = feed
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, nil)
+	if err != nil {
+		res.Err = fmt.Errorf("RSS 요청 생성 실패: %w", err)
+		return res, 0, false
+	}
+	applyValidators(req, state)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		res.Err = fmt.Errorf("RSS 요청 실패: %w", err)
+		return res, 0, true
+	}
+	defer resp.Body.Close()
+
+	res.Header = resp.Header
+	if resp.StatusCode == http.StatusNotModified {
+		res.NotModified = true
+		return res, 0, false
+	}
+	if resp.StatusCode != http.StatusOK {
+		res.Err = fmt.Errorf("RSS 요청 실패: HTTP %s", resp.Status)
+		if backoff.RetryableStatus(resp.StatusCode) {
+			retryAfter, _ = backoff.RetryAfter(resp.Header, time.Now())
+			return res, retryAfter, true
+		}
+		return res, 0, false
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		// 본문 전송 중 끊긴 경우도 네트워크 오류로 간주
+		res.Err = fmt.Errorf("RSS 응답 읽기 실패: %w", err)
+		return res, 0, true
+	}
+
+	res.Format, res.Entries, res.Err = ParseFeed(data, resp.Header.Get("Content-Type"), feed.Charset)
+	return res, 0, false
+}
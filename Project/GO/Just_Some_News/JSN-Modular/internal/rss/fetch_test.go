@@ -0,0 +1,225 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+const (
+	testETag         = `"v1"`
+	testLastModified = "Fri, 16 Oct 2026 00:00:00 GMT"
+	testFeedBody     = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><item>
+  <title>랜섬웨어 조직 검거</title>
+  <link>https://example.com/a</link>
+  <pubDate>Fri, 16 Oct 2026 09:00:00 +0900</pubDate>
+</item></channel></rss>`
+)
+
+// conditionalServer 는 검증자가 맞으면 304, 아니면 검증자와 함께 피드 본문을 돌려주는 시험 서버입니다.
+func conditionalServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Header.Get("If-None-Match") == testETag && r.Header.Get("If-Modified-Since") == testLastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", testETag)
+		w.Header().Set("Last-Modified", testLastModified)
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		_, _ = w.Write([]byte(testFeedBody))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+// testConfig 는 피드 하나를 대기 없이 수집하는 설정입니다.
+func testConfig(url string) *config.Config {
+	cfg := config.Default()
+	cfg.Feeds = []config.Feed{{Name: "test", URL: url, Enabled: true}}
+	cfg.Fetch.HostMinInterval = 0
+	cfg.Retry.BaseDelay = time.Millisecond
+	cfg.Retry.MaxDelay = 50 * time.Millisecond
+	return cfg
+}
+
+func TestFetchFeedConditional(t *testing.T) {
+	srv, _ := conditionalServer(t)
+	feed := config.Feed{Name: "test", URL: srv.URL}
+
+	res := fetchFeed(context.Background(), feed, store.FeedState{}, 1, config.Retry{})
+	if res.Err != nil || res.NotModified {
+		t.Fatalf("첫 요청 = NotModified %v, 오류 %v", res.NotModified, res.Err)
+	}
+	if res.Format != FormatRSS2 || len(res.Entries) != 1 {
+		t.Errorf("첫 요청 = %s, %d건", res.Format, len(res.Entries))
+	}
+	if res.Header.Get("ETag") != testETag || res.Header.Get("Last-Modified") != testLastModified {
+		t.Errorf("검증자 = %q, %q", res.Header.Get("ETag"), res.Header.Get("Last-Modified"))
+	}
+
+	state := store.FeedState{ETag: testETag, LastModified: testLastModified}
+	res = fetchFeed(context.Background(), feed, state, 1, config.Retry{})
+	if res.Err != nil || !res.NotModified || res.Entries != nil {
+		t.Errorf("조건부 요청 = NotModified %v, %d건, 오류 %v, want 304", res.NotModified, len(res.Entries), res.Err)
+	}
+}
+
+func TestCollectConditionalGET(t *testing.T) {
+	srv, hits := conditionalServer(t)
+	cfg := testConfig(srv.URL)
+	st := store.NewMemory(store.Options{})
+	ctx := context.Background()
+
+	run := Collect(ctx, st, cfg)
+	if len(run.Feeds) != 1 || run.Feeds[0].Status != FeedOK || run.New != 1 {
+		t.Fatalf("첫 수집 = %+v", run)
+	}
+	state, _ := st.LoadFeedState(ctx, "test", srv.URL)
+	if state.ETag != testETag || state.LastModified != testLastModified {
+		t.Errorf("저장된 검증자 = %+v", state)
+	}
+
+	run = Collect(ctx, st, cfg)
+	if len(run.Feeds) != 1 || run.Feeds[0].Status != FeedNotModified || run.New != 0 {
+		t.Errorf("두 번째 수집 = %+v, want 304", run)
+	}
+	if n := hits.Load(); n != 2 {
+		t.Errorf("요청 %d회, want 2", n)
+	}
+}
+
+// failingStore 는 기사 저장만 실패하는 저장소입니다.
+type failingStore struct {
+	*store.Memory
+}
+
+func (failingStore) UpsertBatch(context.Context, []store.Article) (store.UpsertResult, error) {
+	return store.UpsertResult{}, errors.New("디스크 가득 참")
+}
+
+func TestCollectStoreFailureKeepsValidators(t *testing.T) {
+	srv, _ := conditionalServer(t)
+	cfg := testConfig(srv.URL)
+	st := failingStore{store.NewMemory(store.Options{})}
+	ctx := context.Background()
+
+	run := Collect(ctx, st, cfg)
+	if len(run.Feeds) != 1 || run.Feeds[0].Status != FeedStoreFailed {
+		t.Fatalf("수집 = %+v, want store_failed", run.Feeds)
+	}
+	// 검증자를 저장하면 다음 실행이 304 를 받아 기사를 영영 놓침
+	if state, _ := st.LoadFeedState(ctx, "test", srv.URL); state.ETag != "" || state.LastModified != "" {
+		t.Errorf("저장 실패 후 검증자가 기록됨: %+v", state)
+	}
+	if run := Collect(ctx, st, cfg); run.Feeds[0].Status != FeedStoreFailed {
+		t.Errorf("다음 수집 = %s, want 전체 본문을 다시 받아 store_failed", run.Feeds[0].Status)
+	}
+}
+
+func TestFetchFeedRetry(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testFeedBody))
+	}))
+	defer srv.Close()
+	feed := config.Feed{Name: "test", URL: srv.URL}
+	retry := config.Retry{BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	if res := fetchFeed(context.Background(), feed, store.FeedState{}, 2, retry); res.Err == nil {
+		t.Error("2회 시도 안에 성공함, want 503")
+	}
+	hits.Store(0)
+	res := fetchFeed(context.Background(), feed, store.FeedState{}, 3, retry)
+	if res.Err != nil || len(res.Entries) != 1 {
+		t.Errorf("3회 시도 = %d건, 오류 %v", len(res.Entries), res.Err)
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("요청 %d회, want 3", n)
+	}
+}
+
+func TestFetchFeedNoRetry(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusForbidden} {
+		var hits atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(status)
+		}))
+		res := fetchFeed(context.Background(), config.Feed{Name: "test", URL: srv.URL}, store.FeedState{}, 3,
+			config.Retry{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+		srv.Close()
+		if res.Err == nil || hits.Load() != 1 {
+			t.Errorf("HTTP %d: 요청 %d회, 오류 %v, want 재시도 없이 실패", status, hits.Load(), res.Err)
+		}
+	}
+}
+
+func TestFetchFeedRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		wantHits   int32
+		wantErr    bool
+	}{
+		// 상한(1초) 안이면 서버가 요구한 만큼 기다린 뒤 재시도
+		{"within cap", "0", 2, false},
+		// 상한을 넘으면 기다리지 않고 이번 실행에서 포기
+		{"over cap", "120", 1, true},
+		{"http date over cap", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if hits.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(testFeedBody))
+			}))
+			defer srv.Close()
+
+			start := time.Now()
+			res := fetchFeed(context.Background(), config.Feed{Name: "test", URL: srv.URL}, store.FeedState{}, 3,
+				config.Retry{BaseDelay: time.Hour, MaxDelay: time.Second})
+			if (res.Err != nil) != tt.wantErr || hits.Load() != tt.wantHits {
+				t.Errorf("요청 %d회, 오류 %v, want %d회 (wantErr %v)", hits.Load(), res.Err, tt.wantHits, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("%s 대기함", elapsed)
+			}
+		})
+	}
+}
+
+func TestFetchFeedCanceledDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res := fetchFeed(ctx, config.Feed{Name: "test", URL: srv.URL}, store.FeedState{}, 3,
+		config.Retry{BaseDelay: time.Hour, MaxDelay: time.Hour})
+	if res.Err == nil || time.Since(start) > 5*time.Second {
+		t.Errorf("취소 후 %s, 오류 %v", time.Since(start), res.Err)
+	}
+}
@@ -0,0 +1,104 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// jsonFeedVersionPrefix 는 JSON Feed 문서의 version 필드 접두어입니다.
+const jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+
+// JSONFeed 는 JSON Feed 1.0/1.1 문서의 매핑 구조체입니다.
+type JSONFeed struct {
+	Version string         `json:"version"`
+	Title   string         `json:"title"`
+	Items   []JSONFeedItem `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Author        *jsonFeedAuthor  `json:"author"` // 1.0 호환 (1.1 에서 deprecated)
+	Tags          []string         `json:"tags"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// isJSONFeedType 은 Content-Type 이 JSON Feed 전용 미디어 타입인지 확인합니다.
+func isJSONFeedType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/feed+json"
+}
+
+// sniffJSONFeed 는 본문이 JSON 객체이고 version 필드가 JSON Feed 인지 확인합니다.
+func sniffJSONFeed(data []byte) bool {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return false
+	}
+	var probe struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return false
+	}
+	return strings.HasPrefix(probe.Version, jsonFeedVersionPrefix)
+}
+
+// parseJSONFeed 는 JSON Feed 문서를 정규화된 Entry 목록으로 변환합니다.
+func parseJSONFeed(data []byte) ([]Entry, error) {
+	var doc JSONFeed
+	if err := json.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &doc); err != nil {
+		return nil, fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+
+	var entries []Entry
+	for _, it := range doc.Items {
+		entries = append(entries, it.entry())
+	}
+	return entries, nil
+}
+
+func (it JSONFeedItem) entry() Entry {
+	link := it.URL
+	if link == "" {
+		link = it.ExternalURL
+	}
+	content := it.ContentHTML
+	if content == "" {
+		content = it.ContentText
+	}
+
+	var authors []string
+	for _, a := range it.Authors {
+		authors = append(authors, a.Name)
+	}
+	if len(authors) == 0 && it.Author != nil {
+		authors = append(authors, it.Author.Name)
+	}
+
+	return Entry{
+		Title:      strings.TrimSpace(it.Title),
+		Link:       strings.TrimSpace(link),
+		Summary:    strings.TrimSpace(it.Summary),
+		Content:    strings.TrimSpace(content),
+		Published:  strings.TrimSpace(it.DatePublished),
+		Updated:    strings.TrimSpace(it.DateModified),
+		Authors:    trimAll(authors),
+		Categories: trimAll(it.Tags),
+		GUID:       strings.TrimSpace(it.ID),
+	}
+}
@@ -0,0 +1,78 @@
+package rss
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONFeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "1.1",
+			data: `{"version": "https://jsonfeed.org/version/1.1", "items": [{
+				"id": "1", "url": " https://example.com/a ", "title": "랜섬웨어",
+				"content_html": "<p>본문</p>", "content_text": "본문", "summary": "요약",
+				"date_published": "2026-10-16T09:00:00+09:00", "date_modified": "2026-10-17T00:00:00Z",
+				"authors": [{"name": "기자"}, {"name": " "}], "tags": ["사건", ""]
+			}]}`,
+			want: []Entry{{
+				Title: "랜섬웨어", Link: "https://example.com/a", Summary: "요약", Content: "<p>본문</p>",
+				Published: "2026-10-16T09:00:00+09:00", Updated: "2026-10-17T00:00:00Z",
+				Authors: []string{"기자"}, Categories: []string{"사건"}, GUID: "1",
+			}},
+		},
+		{
+			// 1.0 의 author, external_url, content_text 대체
+			name: "1.0 fallbacks",
+			data: "\xef\xbb\xbf" + `{"version": "https://jsonfeed.org/version/1", "items": [{
+				"id": "2", "external_url": "https://example.com/b", "content_text": "평문",
+				"author": {"name": "필자"}
+			}]}`,
+			want: []Entry{{Link: "https://example.com/b", Content: "평문", Authors: []string{"필자"}, GUID: "2"}},
+		},
+		{
+			// authors 가 있으면 deprecated author 는 무시
+			name: "authors over author",
+			data: `{"version": "https://jsonfeed.org/version/1.1", "items": [{"id": "3",
+				"authors": [{"name": "새"}], "author": {"name": "옛"}}]}`,
+			want: []Entry{{Authors: []string{"새"}, GUID: "3"}},
+		},
+		{name: "no items", data: `{"version": "https://jsonfeed.org/version/1.1"}`},
+		{name: "broken", data: `{"version": "https://jsonfeed.org/version/1.1", "items": [`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSONFeed([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("오류 = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("항목 =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSniffJSONFeed(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{`{"version": "https://jsonfeed.org/version/1.1"}`, true},
+		{"\xef\xbb\xbf\n {\"version\": \"https://jsonfeed.org/version/1\"}", true},
+		{`{"version": "1.1"}`, false},
+		{`[{"version": "https://jsonfeed.org/version/1.1"}]`, false},
+		{`<rss version="2.0"/>`, false},
+		{``, false},
+	}
+	for _, tt := range tests {
+		if got := sniffJSONFeed([]byte(tt.in)); got != tt.want {
+			t.Errorf("sniffJSONFeed(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package rss
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"jsn-modular/feedcharset"
+)
+
+// Format 은 피드 문서의 포맷입니다.
+type Format string
+
+const (
+	FormatRSS2 Format = "rss2"
+	FormatRDF  Format = "rdf" // RSS 1.0
+	FormatAtom Format = "atom"
+	FormatJSON Format = "json" // JSON Feed 1.0/1.1
+)
+
+const (
+	nsAtom = "http://www.w3.org/2005/Atom"
+	nsRDF  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+)
+
+// Entry 는 포맷과 무관하게 정규화된 기사 모델입니다.
+// Published/Date/Updated 는 피드에 적힌 원문 문자열 그대로이며 resolveDate 로 해석합니다.
+type Entry struct {
+	Title      string
+	Link       string
+	Summary    string
+	Content    string
+	Published  string // pubDate, Atom published, JSON date_published
+	Date       string // dc:date
+	Updated    string // Atom updated, JSON date_modified
+	Authors    []string
+	Categories []string
+	GUID       string
+}
+
+// RSS 는 RSS 2.0 문서의 XML 매핑 구조체입니다.
+type RSS struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel Channel  `xml:"channel"`
+}
+
+type Channel struct {
+	Items []Item `xml:"item"`
+}
+
+// Item 은 RSS 2.0 개별 기사 정보를 담는 구조체입니다.
+type Item struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string   `xml:"pubDate"`
+	Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	GUID        string   `xml:"guid"`
+	Author      string   `xml:"author"`
+	Creators    []string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+}
+
+// RDF 는 RSS 1.0 (RDF) 문서의 XML 매핑 구조체입니다. item 은 channel 밖에 위치합니다.
+type RDF struct {
+	XMLName xml.Name  `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
+	Items   []RDFItem `xml:"item"`
+}
+
+type RDFItem struct {
+	About       string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Creators    []string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Subjects    []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+}
+
+// Atom 은 Atom 1.0 문서의 XML 매핑 구조체입니다.
+type Atom struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      atomText       `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Summary    atomText       `xml:"summary"`
+	Content    atomText       `xml:"content"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// atomText 는 type="xhtml" 이면 마크업을, 그 외에는 디코딩된 텍스트를 담습니다.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Inner string `xml:",innerxml"`
+	Text  string `xml:",chardata"`
+}
+
+func (t atomText) String() string {
+	if t.Type == "xhtml" {
+		return strings.TrimSpace(t.Inner)
+	}
+	return strings.TrimSpace(t.Text)
+}
+
+// ParseFeed 는 문서의 포맷을 판별하고 정규화된 Entry 목록으로 변환합니다.
+// contentType 은 HTTP 응답 헤더 값이며, charset 이 지정되면 XML 선언보다 우선해
+// 본문을 UTF-8 로 변환합니다. 인코딩 결정 순서는 feedcharset.Resolve 를 따릅니다.
+func ParseFeed(data []byte, contentType, charset string) (Format, []Entry, error) {
+	format, err := DetectFormat(data, contentType, charset)
+	if err != nil {
+		return "", nil, err
+	}
+	if format == FormatJSON {
+		entries, err := parseJSONFeed(data)
+		return format, entries, err
+	}
+
+	decoder, err := feedcharset.NewDecoder(data, contentType, charset)
+	if err != nil {
+		return format, nil, err
+	}
+
+	var entries []Entry
+	switch format {
+	case FormatRSS2:
+		var doc RSS
+		if err := decoder.Decode(&doc); err != nil {
+			return format, nil, fmt.Errorf("XML 파싱 실패: %w", err)
+		}
+		for _, it := range doc.Channel.Items {
+			entries = append(entries, it.entry())
+		}
+	case FormatRDF:
+		var doc RDF
+		if err := decoder.Decode(&doc); err != nil {
+			return format, nil, fmt.Errorf("XML 파싱 실패: %w", err)
+		}
+		for _, it := range doc.Items {
+			entries = append(entries, it.entry())
+		}
+	case FormatAtom:
+		var doc Atom
+		if err := decoder.Decode(&doc); err != nil {
+			return format, nil, fmt.Errorf("XML 파싱 실패: %w", err)
+		}
+		for _, e := range doc.Entries {
+			entries = append(entries, e.entry())
+		}
+	}
+	return format, entries, nil
+}
+
+// DetectFormat 은 Content-Type 과 본문을 보고 피드 포맷을 판별합니다.
+// JSON Feed 가 아니면 XML 루트 엘리먼트로 판별합니다.
+func DetectFormat(data []byte, contentType, charset string) (Format, error) {
+	if isJSONFeedType(contentType) || sniffJSONFeed(data) {
+		return FormatJSON, nil
+	}
+
+	decoder, err := feedcharset.NewDecoder(data, contentType, charset)
+	if err != nil {
+		return "", err
+	}
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", errors.New("XML 파싱 실패: 루트 엘리먼트 없음")
+			}
+			return "", fmt.Errorf("XML 파싱 실패: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case start.Name.Local == "rss":
+			return FormatRSS2, nil
+		case start.Name.Local == "RDF" && start.Name.Space == nsRDF:
+			return FormatRDF, nil
+		case start.Name.Local == "feed" && start.Name.Space == nsAtom:
+			return FormatAtom, nil
+		}
+		return "", fmt.Errorf("지원하지 않는 피드 포맷: <%s>", start.Name.Local)
+	}
+}
+
+func (it Item) entry() Entry {
+	authors := it.Creators
+	if it.Author != "" {
+		authors = append([]string{it.Author}, authors...)
+	}
+	return Entry{
+		Title:      strings.TrimSpace(it.Title),
+		Link:       strings.TrimSpace(it.Link),
+		Summary:    strings.TrimSpace(it.Description),
+		Content:    strings.TrimSpace(it.Content),
+		Published:  strings.TrimSpace(it.PubDate),
+		Date:       strings.TrimSpace(it.Date),
+		Authors:    trimAll(authors),
+		Categories: trimAll(it.Categories),
+		GUID:       strings.TrimSpace(it.GUID),
+	}
+}
+
+func (it RDFItem) entry() Entry {
+	return Entry{
+		Title:      strings.TrimSpace(it.Title),
+		Link:       strings.TrimSpace(it.Link),
+		Summary:    strings.TrimSpace(it.Description),
+		Content:    strings.TrimSpace(it.Content),
+		Date:       strings.TrimSpace(it.Date),
+		Authors:    trimAll(it.Creators),
+		Categories: trimAll(it.Subjects),
+		GUID:       strings.TrimSpace(it.About),
+	}
+}
+
+func (e AtomEntry) entry() Entry {
+	var link string
+	for _, l := range e.Links {
+		// rel 이 없으면 alternate 로 간주 (RFC 4287 4.2.7.2)
+		if l.Rel == "" || l.Rel == "alternate" {
+			link = l.Href
+			break
+		}
+	}
+	if link == "" && len(e.Links) > 0 {
+		link = e.Links[0].Href
+	}
+
+	var authors, categories []string
+	for _, a := range e.Authors {
+		authors = append(authors, a.Name)
+	}
+	for _, c := range e.Categories {
+		if c.Label != "" {
+			categories = append(categories, c.Label)
+		} else {
+			categories = append(categories, c.Term)
+		}
+	}
+
+	return Entry{
+		Title:      e.Title.String(),
+		Link:       strings.TrimSpace(link),
+		Summary:    e.Summary.String(),
+		Content:    e.Content.String(),
+		Published:  strings.TrimSpace(e.Published),
+		Updated:    strings.TrimSpace(e.Updated),
+		Authors:    trimAll(authors),
+		Categories: trimAll(categories),
+		GUID:       strings.TrimSpace(e.ID),
+	}
+}
+
+// trimAll 은 공백을 정리하고 빈 값을 제거합니다.
+func trimAll(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package rss
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const rss2Doc = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+<channel>
+  <title>보안뉴스</title>
+  <item>
+    <title> 랜섬웨어 조직 검거 </title>
+    <link>https://example.com/a</link>
+    <description><![CDATA[<p>요약</p>]]></description>
+    <content:encoded><![CDATA[<p>본문</p>]]></content:encoded>
+    <pubDate>Fri, 16 Oct 2026 09:00:00 +0900</pubDate>
+    <guid>a-1</guid>
+    <author>sec@example.com</author>
+    <dc:creator>홍길동</dc:creator>
+    <category>사건</category>
+    <category> </category>
+  </item>
+</channel>
+</rss>`
+
+const rdfDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel rdf:about="https://example.com/"><title>RDF</title></channel>
+  <item rdf:about="https://example.com/r">
+    <title>RSS 1.0 기사</title>
+    <link>https://example.com/r</link>
+    <description>설명</description>
+    <dc:date>2026-10-16T09:00:00+09:00</dc:date>
+    <dc:creator>기자</dc:creator>
+    <dc:subject>취약점</dc:subject>
+  </item>
+</rdf:RDF>`
+
+const atomDoc = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom</title>
+  <entry>
+    <id>urn:uuid:1</id>
+    <title type="html">패치 &amp;lt;긴급&amp;gt;</title>
+    <link rel="self" href="https://example.com/self"/>
+    <link href="https://example.com/alt"/>
+    <summary>요약</summary>
+    <content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>본문</p></div></content>
+    <published>2026-10-16T00:00:00Z</published>
+    <updated>2026-10-17T00:00:00Z</updated>
+    <author><name>기자</name></author>
+    <category term="vuln" label="취약점"/>
+    <category term="cve"/>
+  </entry>
+  <entry>
+    <id>urn:uuid:2</id>
+    <title>링크 rel 이 모두 다름</title>
+    <link rel="enclosure" href="https://example.com/a.mp3"/>
+  </entry>
+</feed>`
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+		want   []Entry
+	}{
+		{"rss2", rss2Doc, FormatRSS2, []Entry{{
+			Title:      "랜섬웨어 조직 검거",
+			Link:       "https://example.com/a",
+			Summary:    "<p>요약</p>",
+			Content:    "<p>본문</p>",
+			Published:  "Fri, 16 Oct 2026 09:00:00 +0900",
+			Authors:    []string{"sec@example.com", "홍길동"},
+			Categories: []string{"사건"},
+			GUID:       "a-1",
+		}}},
+		{"rdf", rdfDoc, FormatRDF, []Entry{{
+			Title:      "RSS 1.0 기사",
+			Link:       "https://example.com/r",
+			Summary:    "설명",
+			Date:       "2026-10-16T09:00:00+09:00",
+			Authors:    []string{"기자"},
+			Categories: []string{"취약점"},
+			GUID:       "https://example.com/r",
+		}}},
+		{"atom", atomDoc, FormatAtom, []Entry{
+			{
+				Title:      "패치 &lt;긴급&gt;",
+				Link:       "https://example.com/alt",
+				Summary:    "요약",
+				Content:    `<div xmlns="http://www.w3.org/1999/xhtml"><p>본문</p></div>`,
+				Published:  "2026-10-16T00:00:00Z",
+				Updated:    "2026-10-17T00:00:00Z",
+				Authors:    []string{"기자"},
+				Categories: []string{"취약점", "cve"},
+				GUID:       "urn:uuid:1",
+			},
+			// alternate 가 없으면 첫 링크
+			{Title: "링크 rel 이 모두 다름", Link: "https://example.com/a.mp3", GUID: "urn:uuid:2"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, entries, err := ParseFeed([]byte(tt.data), "application/xml", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.format {
+				t.Errorf("포맷 = %q, want %q", format, tt.format)
+			}
+			if !reflect.DeepEqual(entries, tt.want) {
+				t.Errorf("항목 =\n%+v\nwant\n%+v", entries, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		want        Format
+		wantErr     string
+	}{
+		{"rss2", rss2Doc, "", FormatRSS2, ""},
+		{"rdf", rdfDoc, "", FormatRDF, ""},
+		{"atom", atomDoc, "", FormatAtom, ""},
+		{"json by content type", `{"items": []}`, "application/feed+json; charset=utf-8", FormatJSON, ""},
+		{"json by version", ` {"version": "https://jsonfeed.org/version/1.1", "items": []}`, "application/json", FormatJSON, ""},
+		{"comment before root", "<!-- 생성기 --><rss version=\"2.0\"/>", "", FormatRSS2, ""},
+		{"feed without atom namespace", "<feed/>", "", "", "지원하지 않는 피드 포맷"},
+		{"html", "<html><body/></html>", "text/html", "", "지원하지 않는 피드 포맷"},
+		{"plain json", `{"version": "1"}`, "application/json", "", "XML 파싱 실패"},
+		{"empty", "", "", "", "루트 엘리먼트 없음"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFormat([]byte(tt.data), tt.contentType, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("오류 = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectFormat = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
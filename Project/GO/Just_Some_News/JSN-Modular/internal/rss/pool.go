@@ -0,0 +1,135 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+)
+
+// fetchJob 은 워커 풀에 전달되는 피드 하나의 작업입니다.
+type fetchJob struct {
+	Feed  config.Feed
+	State store.FeedState
+	Probe bool // 서킷 쿨다운 후 프로브 요청이면 재시도 없이 한 번만 시도
+}
+
+// fetchAll 은 크기가 제한된 워커 풀로 피드를 동시에 가져옵니다.
+// 결과 채널은 모든 작업이 끝나면 닫힙니다.
+func fetchAll(ctx context.Context, jobs []fetchJob, workers int, limiter *hostLimiter, retry config.Retry) <-chan fetchResult {
+	if workers < 1 {
+		workers = 1
+	}
+	queue := make(chan fetchJob)
+	results := make(chan fetchResult)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for job := range queue {
+				release, err := limiter.acquire(ctx, hostOf(job.Feed.URL))
+				if err != nil {
+					// 종료 중이거나 시간 초과: 이 피드는 건너뜀
+					results <- fetchResult{Feed: job.Feed, Err: fmt.Errorf("호스트 대기 중 취소: %w", err)}
+					continue
+				}
+				attempts := retry.MaxAttempts
+				if job.Probe {
+					attempts = 1
+				}
+				res := fetchFeed(ctx, job.Feed, job.State, attempts, retry)
+				release()
+				results <- res
+			}
+		})
+	}
+
+	go func() {
+		for _, job := range jobs {
+			queue <- job
+		}
+		close(queue)
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// hostLimiter 는 호스트별 동시 요청 수와 요청 간 최소 간격을 제한합니다.
+type hostLimiter struct {
+	mu          sync.Mutex
+	hosts       map[string]*hostSlot
+	maxConc     int
+	minInterval time.Duration
+}
+
+type hostSlot struct {
+	sem  chan struct{}
+	mu   sync.Mutex
+	next time.Time // 다음 요청이 허용되는 시각
+}
+
+func newHostLimiter(maxConc int, minInterval time.Duration) *hostLimiter {
+	if maxConc < 1 {
+		maxConc = 1
+	}
+	return &hostLimiter{
+		hosts:       make(map[string]*hostSlot),
+		maxConc:     maxConc,
+		minInterval: minInterval,
+	}
+}
+
+// acquire 는 호스트 슬롯을 확보하고 필요한 만큼 대기한 뒤 반납 함수를 반환합니다.
+// 슬롯이나 요청 간격을 기다리는 동안 ctx 가 취소되면 ctx 의 오류를 반환합니다.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (release func(), err error) {
+	l.mu.Lock()
+	slot, ok := l.hosts[host]
+	if !ok {
+		slot = &hostSlot{sem: make(chan struct{}, l.maxConc)}
+		l.hosts[host] = slot
+	}
+	l.mu.Unlock()
+
+	select {
+	case slot.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	slot.mu.Lock()
+	now := time.Now()
+	start := slot.next
+	if start.Before(now) {
+		start = now
+	}
+	slot.next = start.Add(l.minInterval)
+	slot.mu.Unlock()
+
+	release = func() { <-slot.sem }
+	if wait := time.Until(start); wait > 0 {
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			release()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+	return release, nil
+}
+
+// hostOf 는 URL 의 호스트(소문자)를 반환합니다. 파싱에 실패하면 URL 자체를 키로 씁니다.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Hostname())
+}
@@ -0,0 +1,54 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHostLimiterAcquireCanceled(t *testing.T) {
+	l := newHostLimiter(1, time.Hour)
+	release, err := l.acquire(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 슬롯이 찬 상태에서 기다리다 시간 초과
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("슬롯 대기 오류 = %v, want DeadlineExceeded", err)
+	}
+	release()
+
+	// 슬롯은 비었지만 요청 간격(1시간) 대기 중 취소 → 슬롯을 돌려줘야 함
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	if _, err := l.acquire(ctx2, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("간격 대기 오류 = %v, want DeadlineExceeded", err)
+	}
+	if n := len(l.hosts["example.com"].sem); n != 0 {
+		t.Errorf("취소 후 사용 중인 슬롯 %d개, want 0", n)
+	}
+
+	// 다른 호스트는 영향 없음
+	if release, err := l.acquire(context.Background(), "EXAMPLE.org"); err != nil {
+		t.Errorf("다른 호스트 acquire 오류: %v", err)
+	} else {
+		release()
+	}
+}
+
+func TestHostOf(t *testing.T) {
+	tests := map[string]string{
+		"https://WWW.Boannews.com/media/news_rss.xml": "www.boannews.com",
+		"http://example.com:8080/feed":                "example.com",
+		"not a url":                                   "not a url",
+	}
+	for in, want := range tests {
+		if got := hostOf(in); got != want {
+			t.Errorf("hostOf(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package rss
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FeedStatus 는 한 번의 실행에서 피드 하나가 끝난 상태입니다.
+type FeedStatus string
+
+const (
+	FeedOK          FeedStatus = "ok"           // 가져와서 저장까지 성공
+	FeedNotModified FeedStatus = "not_modified" // 304 응답
+	FeedFailed      FeedStatus = "failed"       // 요청 또는 파싱 실패 (재시도 후)
+	FeedStoreFailed FeedStatus = "store_failed" // 저장 실패 (롤백)
+	FeedCircuitOpen FeedStatus = "circuit_open" // 연속 실패로 쿨다운 중이라 건너뜀
+	FeedCanceled    FeedStatus = "canceled"     // 종료 요청으로 취소
+)
+
+// FeedResult 는 피드 하나의 실행 결과입니다.
+type FeedResult struct {
+	Feed      string
+	Status    FeedStatus
+	New       int
+	Updated   int
+	Unchanged int
+	Err       error // FeedFailed, FeedStoreFailed 의 원인
+}
+
+// Failed 는 이번 실행에서 피드를 수집하지 못했는지 여부입니다. 서킷 오픈도 실패로 봅니다.
+func (r FeedResult) Failed() bool {
+	switch r.Status {
+	case FeedFailed, FeedStoreFailed, FeedCircuitOpen:
+		return true
+	}
+	return false
+}
+
+// RunResult 는 Collect 한 번의 결과입니다. 비활성 피드는 포함하지 않습니다.
+type RunResult struct {
+	Started  time.Time
+	Finished time.Time
+	Feeds    []FeedResult
+	New      int
+	Updated  int
+	Canceled bool // 실행 도중 ctx 가 취소됨
+}
+
+// Failed 는 수집하지 못한 피드의 결과입니다.
+func (r RunResult) Failed() []FeedResult {
+	var out []FeedResult
+	for _, f := range r.Feeds {
+		if f.Failed() {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// AllFailed 는 대상 피드가 하나 이상 있고 모두 실패했는지 여부입니다.
+func (r RunResult) AllFailed() bool {
+	return len(r.Feeds) > 0 && len(r.Failed()) == len(r.Feeds)
+}
+
+// Err 는 실패한 피드의 오류를 "[피드] 원인" 형식으로 묶어 반환합니다. 실패가 없으면 nil 입니다.
+func (r RunResult) Err() error {
+	var errs []error
+	for _, f := range r.Failed() {
+		cause := f.Err
+		if cause == nil {
+			cause = errors.New(string(f.Status))
+		}
+		errs = append(errs, fmt.Errorf("[%s] %w", f.Feed, cause))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,24 @@
+package rss
+
+import (
+	"net/http"
+	"time"
+
+	"jsn-modular/internal/store"
+)
+
+// breakerOpen 은 연속 실패가 임계치에 도달했고 아직 쿨다운 중이면 true 를 반환합니다.
+// 쿨다운이 지났다면 false 를 반환해 프로브 요청을 허용합니다.
+func breakerOpen(st store.FeedState, threshold int, cooldown time.Duration) bool {
+	return st.Failures >= threshold && time.Since(st.LastFailure) < cooldown
+}
+
+// applyValidators 는 저장된 검증자를 If-None-Match / If-Modified-Since 헤더로 설정합니다.
+func applyValidators(req *http.Request, st store.FeedState) {
+	if st.ETag != "" {
+		req.Header.Set("If-None-Match", st.ETag)
+	}
+	if st.LastModified != "" {
+		req.Header.Set("If-Modified-Since", st.LastModified)
+	}
+}
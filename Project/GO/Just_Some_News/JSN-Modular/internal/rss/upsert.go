@@ -0,0 +1,94 @@
+package rss
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/sanitize"
+	"jsn-modular/internal/simhash"
+	"jsn-modular/internal/store"
+	"jsn-modular/urlnorm"
+)
+
+// buildRows 는 파싱된 기사를 저장할 행으로 변환합니다.
+// 링크 해석/정규화에 실패한 항목은 건너뛰고, 같은 정규화 URL 은 먼저 나온 것만 남깁니다.
+func buildRows(feed config.Feed, entries []Entry) []store.Article {
+	seen := make(map[string]bool)
+	var rows []store.Article
+	for _, item := range entries {
+		// 상대 링크는 피드 URL 기준으로 해석하고, 정규화 URL 을 중복 판정 키로 사용
+		link, err := urlnorm.Resolve(item.Link, feed.URL)
+		if err != nil {
+			log.Printf(">>> [%s] 링크 해석 실패: %q: %v", feed.Name, item.Link, err)
+			continue
+		}
+		canonical, err := urlnorm.Canonical(link, "")
+		if err != nil {
+			log.Printf(">>> [%s] 링크 정규화 실패: %q: %v", feed.Name, link, err)
+			continue
+		}
+		if seen[canonical] {
+			continue
+		}
+		seen[canonical] = true
+
+		// 날짜를 해석하지 못하면 수집 시각으로 대체하고 date_guessed 로 표시
+		// DATETIME 은 초 단위로 저장되므로 기존 행과 비교할 수 있게 잘라 둠
+		t, guessed := resolveDate(item, time.Now())
+		t = t.Truncate(time.Second)
+
+		raw := item.Summary
+		if raw == "" {
+			raw = item.Content
+		}
+		// description 은 허용 목록으로 정리한 HTML, description_text 는 검색/알림용 평문
+		description, descriptionText := sanitize.HTML(raw), sanitize.Text(raw)
+
+		rows = append(rows, store.Article{
+			Source:          feed.Name,
+			Title:           item.Title,
+			Link:            link,
+			Canonical:       canonical,
+			PubDate:         t,
+			DateGuessed:     guessed,
+			Description:     description,
+			DescriptionText: descriptionText,
+			SimHash:         simhash.Fingerprint(sanitize.Text(item.Title) + " " + descriptionText),
+		})
+	}
+	return rows
+}
+
+// upsertArticles 는 피드 하나의 기사를 저장소에 한 번에 upsert 합니다.
+// 실패하면 저장소가 전체를 롤백하므로 부분 저장은 없습니다.
+func upsertArticles(ctx context.Context, st store.ArticleStore, feed config.Feed, articles []store.Article) (feedStats, error) {
+	stats := feedStats{Scanned: len(articles)}
+	res, err := st.UpsertBatch(ctx, articles)
+	if err != nil {
+		return feedStats{}, err
+	}
+
+	byLink := make(map[string]store.Article, len(articles))
+	for _, a := range articles {
+		byLink[a.Link] = a
+	}
+	for _, a := range res.New {
+		row := byLink[a.Link]
+		row.ID = a.ID
+		stats.Inserted = append(stats.Inserted, row)
+		if a.Joined {
+			log.Printf(">>> 유사 기사 묶음 #%d 에 합류: [%s] %s", a.ClusterID, feed.Name, a.Title)
+		}
+		if row.DateGuessed {
+			log.Printf(">>> [%s] 날짜 해석 실패, 수집 시각으로 대체: %s", feed.Name, a.Title)
+		}
+		log.Printf(">>> 신규 수집: [%s] %s", feed.Name, a.Title)
+		if feed.FullText {
+			stats.Pending = append(stats.Pending, pendingArticle{ID: a.ID, Feed: feed.Name, Link: a.Link})
+		}
+	}
+	stats.New, stats.Updated, stats.Unchanged = res.Inserted, res.Updated, res.Unchanged
+	return stats, nil
+}
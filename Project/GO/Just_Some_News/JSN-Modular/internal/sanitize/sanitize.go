@@ -0,0 +1,171 @@
+// Package sanitize cleans feed HTML down to an allow-list and normalizes it to plain text.
+package sanitize
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags 는 허용하는 태그와 태그별 허용 속성입니다.
+// 목록에 없는 태그는 태그만 벗기고 내용은 유지합니다.
+var allowedTags = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.B: nil, atom.Strong: nil, atom.I: nil,
+	atom.Em: nil, atom.U: nil, atom.S: nil, atom.Ul: nil, atom.Ol: nil,
+	atom.Li: nil, atom.Blockquote: nil, atom.Code: nil, atom.Pre: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.A:   {"href", "title"},
+	atom.Img: {"src", "alt", "title"},
+}
+
+// droppedTags 는 내용까지 통째로 제거하는 태그입니다.
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Noscript: true, atom.Template: true, atom.Form: true,
+	atom.Frame: true, atom.Frameset: true, atom.Svg: true, atom.Math: true,
+	atom.Link: true, atom.Meta: true, atom.Head: true, atom.Title: true,
+}
+
+// voidTags 는 닫는 태그가 없는 허용 태그입니다.
+var voidTags = map[atom.Atom]bool{atom.Br: true, atom.Img: true}
+
+// blockTags 는 평문 변환 시 단어가 붙지 않도록 공백으로 구분하는 태그입니다.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Div: true, atom.Li: true, atom.Tr: true,
+	atom.Td: true, atom.Th: true, atom.Blockquote: true, atom.Pre: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// HTML 은 허용 목록에 있는 태그와 속성만 남긴 HTML 을 반환합니다.
+// script/style/iframe 등은 내용째 제거하고, on* 이벤트 핸들러와 javascript: URL,
+// 1x1 추적 픽셀 이미지를 버립니다.
+func HTML(s string) string {
+	nodes, err := parseFragment(s)
+	if err != nil {
+		return html.EscapeString(s)
+	}
+	var sb strings.Builder
+	for _, n := range nodes {
+		render(&sb, n)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Text 는 태그를 제거하고 엔티티를 디코딩한 뒤 공백을 하나로 합친 평문을 반환합니다.
+func Text(s string) string {
+	nodes, err := parseFragment(s)
+	if err != nil {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	var sb strings.Builder
+	for _, n := range nodes {
+		collectText(&sb, n)
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+func parseFragment(s string) ([]*html.Node, error) {
+	ctx := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	return html.ParseFragment(strings.NewReader(s), ctx)
+}
+
+func render(sb *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		// 주석, doctype 등은 제거
+		return
+	}
+	if droppedTags[n.DataAtom] {
+		return
+	}
+
+	attrs, allowed := allowedTags[n.DataAtom]
+	if allowed && n.DataAtom == atom.Img && isTrackingPixel(n) {
+		return
+	}
+	if allowed {
+		sb.WriteByte('<')
+		sb.WriteString(n.Data)
+		for _, a := range n.Attr {
+			if a.Namespace != "" || !slices.Contains(attrs, a.Key) {
+				continue
+			}
+			if (a.Key == "href" || a.Key == "src") && !safeURL(a.Val) {
+				continue
+			}
+			sb.WriteByte(' ')
+			sb.WriteString(a.Key)
+			sb.WriteString(`="`)
+			sb.WriteString(html.EscapeString(a.Val))
+			sb.WriteByte('"')
+		}
+		sb.WriteByte('>')
+		if voidTags[n.DataAtom] {
+			return
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		render(sb, c)
+	}
+	if allowed {
+		sb.WriteString("</")
+		sb.WriteString(n.Data)
+		sb.WriteByte('>')
+	}
+}
+
+func collectText(sb *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if droppedTags[n.DataAtom] {
+			return
+		}
+	}
+	block := n.Type == html.ElementNode && blockTags[n.DataAtom]
+	if block {
+		sb.WriteByte(' ')
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectText(sb, c)
+	}
+	if block {
+		sb.WriteByte(' ')
+	}
+}
+
+// safeURL 은 http, https, mailto 스킴이거나 상대 경로인 URL 만 허용합니다.
+func safeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// isTrackingPixel 은 가로 또는 세로가 1px 이하인 이미지를 추적 픽셀로 판단합니다.
+func isTrackingPixel(n *html.Node) bool {
+	for _, a := range n.Attr {
+		if a.Key != "width" && a.Key != "height" {
+			continue
+		}
+		if v, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(a.Val), "px")); err == nil && v <= 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package sanitize
+
+import "testing"
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"allowed tags kept", `<p>경찰이 <b>검거</b><br>했다</p>`, `<p>경찰이 <b>검거</b><br>했다</p>`},
+		{"script dropped with content", `<p>본문</p><script>alert(1)</script>`, `<p>본문</p>`},
+		{"style and iframe dropped", `<style>p{}</style><iframe src="https://evil.example"></iframe>남음`, `남음`},
+		{"unknown tag unwrapped", `<div><span class="x">글자</span></div>`, `글자`},
+		{"event handlers removed", `<p onclick="steal()">a</p><img src="/a.png" onerror="steal()" alt="그림">`,
+			`<p>a</p><img src="/a.png" alt="그림">`},
+		{"style attribute removed", `<a href="https://example.com" style="color:red" target="_blank">링크</a>`,
+			`<a href="https://example.com">링크</a>`},
+		{"https link kept", `<a href="https://example.com/a?b=1&amp;c=2" title="제목">x</a>`,
+			`<a href="https://example.com/a?b=1&amp;c=2" title="제목">x</a>`},
+		{"relative and mailto kept", `<a href="/news/1">a</a><a href="mailto:sec@example.com">b</a>`,
+			`<a href="/news/1">a</a><a href="mailto:sec@example.com">b</a>`},
+		{"javascript URL removed", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"mixed case javascript URL removed", `<a href=" JavaScript:alert(1)">x</a>`, `<a>x</a>`},
+		{"data URL removed", `<img src="data:image/png;base64,AAAA" alt="a">`, `<img alt="a">`},
+		{"tracking pixel dropped", `<p>a<img src="https://t.example/p.gif" width="1" height="1"></p>`, `<p>a</p>`},
+		{"tracking pixel with px", `<img src="/p.gif" height="0px">`, ``},
+		{"normal image kept", `<img src="/a.png" width="640">`, `<img src="/a.png">`},
+		{"comment removed", `a<!-- 광고 -->b`, `ab`},
+		{"text escaped", `5 &lt; 6 &amp; "따옴표"`, `5 &lt; 6 &amp; &#34;따옴표&#34;`},
+		{"unclosed tag closed", `<p><b>굵게`, `<p><b>굵게</b></p>`},
+	}
+	for _, tt := range tests {
+		if got := HTML(tt.in); got != tt.want {
+			t.Errorf("%s: HTML(%q) =\n%q\nwant\n%q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"tags stripped", `<p>경찰이 <b>검거</b>했다</p>`, `경찰이 검거했다`},
+		{"blocks separated", `<p>첫 문단</p><p>둘째</p><ul><li>a</li><li>b</li></ul>줄<br>바꿈`, `첫 문단 둘째 a b 줄 바꿈`},
+		{"script and style dropped", `<script>var x = 1;</script><style>p{}</style>본문`, `본문`},
+		{"entities decoded", `5 &lt; 6 &amp;&nbsp;&quot;A&quot; &#54620;`, `5 < 6 & "A" 한`},
+		{"whitespace collapsed", "  줄\n\t바꿈   공백 ", `줄 바꿈 공백`},
+		{"plain text", `그냥 글자`, `그냥 글자`},
+	}
+	for _, tt := range tests {
+		if got := Text(tt.in); got != tt.want {
+			t.Errorf("%s: Text(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package schedule parses cron expressions and runs jobs on them without overlap.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 은 다음 실행 시각을 계산합니다.
+type Schedule interface {
+	// Next 는 t 이후의 첫 실행 시각을 반환합니다.
+	Next(t time.Time) time.Time
+}
+
+// Every 는 고정 간격 일정입니다.
+type Every time.Duration
+
+func (e Every) Next(t time.Time) time.Time { return t.Add(time.Duration(e)) }
+
+// descriptors 는 자주 쓰는 cron 식의 별칭입니다.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse 는 일정 문자열을 해석합니다.
+//
+//	0 * * * *       표준 cron 5필드 (분 시 일 월 요일), 목록/범위/간격(*/15, 1-5, 1,3) 지원
+//	@hourly         @yearly, @monthly, @weekly, @daily, @hourly 별칭
+//	@every 30m      고정 간격 (time.ParseDuration 형식)
+//
+// 시각은 time.Local 기준입니다 (TZ 환경 변수로 변경).
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, fmt.Errorf("일정 %q: 간격 형식 오류: %w", spec, err)
+		}
+		if every < time.Second {
+			return nil, fmt.Errorf("일정 %q: 간격은 1초 이상이어야 함", spec)
+		}
+		return Every(every), nil
+	}
+	if expr, ok := descriptors[spec]; ok {
+		spec = expr
+	}
+
+	f := strings.Fields(spec)
+	if len(f) != 5 {
+		return nil, fmt.Errorf("일정 %q: cron 식은 5필드 (분 시 일 월 요일) 여야 함", spec)
+	}
+	var c cron
+	var err error
+	for i, b := range []struct {
+		dst      *uint64
+		min, max int
+	}{{&c.minute, 0, 59}, {&c.hour, 0, 23}, {&c.dom, 1, 31}, {&c.month, 1, 12}, {&c.dow, 0, 7}} {
+		if *b.dst, err = parseField(f[i], b.min, b.max); err != nil {
+			return nil, fmt.Errorf("일정 %q: %w", spec, err)
+		}
+	}
+	// 요일 7 은 일요일(0)과 같음
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	// 일/요일이 * 로 시작하면 제한 없음으로 봄 (둘 다 제한했을 때만 OR 조건)
+	c.domStar = strings.HasPrefix(f[2], "*") || f[2] == "?"
+	c.dowStar = strings.HasPrefix(f[4], "*") || f[4] == "?"
+	return c, nil
+}
+
+// cron 은 필드별 허용 값을 비트로 담은 cron 식입니다.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// parseField 는 "*", "*/15", "1-5", "1-10/2", "1,3,5" 형식의 필드를 비트 집합으로 바꿉니다.
+func parseField(s string, min, max int) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("잘못된 간격: %q", part)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(a)
+			hi, err2 = strconv.Atoi(b)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("잘못된 범위: %q", part)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, fmt.Errorf("잘못된 값: %q", part)
+			}
+			lo, hi = n, n
+			if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q: %d~%d 범위를 벗어남", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Next 는 t 이후(초 단위 버림) 조건을 만족하는 첫 분을 찾습니다. 5년 안에 없으면 0 시각을 반환합니다.
+func (c cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches 는 일/요일 조건을 봅니다. 둘 다 지정하면 cron 관례대로 둘 중 하나만 맞아도 됩니다.
+func (c cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dow
+	case c.dowStar:
+		return dom
+	}
+	return dom || dow
+}
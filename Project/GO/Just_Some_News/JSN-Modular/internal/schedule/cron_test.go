@@ -0,0 +1,107 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func bitsOf(vals ...int) uint64 {
+	var b uint64
+	for _, v := range vals {
+		b |= 1 << v
+	}
+	return b
+}
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		want     uint64
+		wantErr  bool
+	}{
+		{"*", 0, 6, bitsOf(0, 1, 2, 3, 4, 5, 6), false},
+		{"*/15", 0, 59, bitsOf(0, 15, 30, 45), false},
+		{"1-10/3", 0, 59, bitsOf(1, 4, 7, 10), false},
+		{"9-17/4", 0, 23, bitsOf(9, 13, 17), false},
+		{"5/20", 0, 59, bitsOf(5, 25, 45), false},
+		{"1,3,5", 0, 6, bitsOf(1, 3, 5), false},
+		{"1-3,10", 1, 31, bitsOf(1, 2, 3, 10), false},
+		{"7", 0, 7, bitsOf(7), false},
+		{"60", 0, 59, 0, true},
+		{"0", 1, 31, 0, true},
+		{"5-1", 0, 59, 0, true},
+		{"*/0", 0, 59, 0, true},
+		{"1-", 0, 59, 0, true},
+		{"mon", 0, 7, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseField(tt.field, tt.min, tt.max)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseField(%q, %d, %d) 오류 = %v, wantErr %v", tt.field, tt.min, tt.max, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseField(%q, %d, %d) = %b, want %b", tt.field, tt.min, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2026-10-16 은 금요일
+	from := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+	at := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute drops seconds", "* * * * *", from, at(2026, 10, 16, 10, 8)},
+		{"every 15 minutes", "*/15 * * * *", from, at(2026, 10, 16, 10, 15)},
+		{"range with step", "0 9-17/4 * * *", from, at(2026, 10, 16, 13, 0)},
+		{"range with step next day", "0 9-17/4 * * *", at(2026, 10, 16, 17, 0), at(2026, 10, 17, 9, 0)},
+		{"7 is Sunday", "0 0 * * 7", from, at(2026, 10, 18, 0, 0)},
+		{"0 is Sunday", "0 0 * * 0", from, at(2026, 10, 18, 0, 0)},
+		{"weekly descriptor", "@weekly", from, at(2026, 10, 18, 0, 0)},
+		{"dom or dow: Monday first", "0 0 20 * 1", from, at(2026, 10, 19, 0, 0)},
+		{"dom or dow: day 20 next", "0 0 20 * 1", at(2026, 10, 19, 0, 0), at(2026, 10, 20, 0, 0)},
+		{"dom only when dow is star", "0 0 20 * *", from, at(2026, 10, 20, 0, 0)},
+		{"dow only when dom is star", "0 0 * * 1-5", at(2026, 10, 16, 23, 0), at(2026, 10, 19, 0, 0)},
+		{"month rollover", "0 0 1 * *", from, at(2026, 11, 1, 0, 0)},
+		{"year rollover", "0 0 1 * *", at(2026, 12, 31, 23, 59), at(2027, 1, 1, 0, 0)},
+		{"skips months without day 31", "0 0 31 * *", at(2026, 10, 31, 0, 0), at(2026, 12, 31, 0, 0)},
+		{"leap day", "0 0 29 2 *", from, at(2028, 2, 29, 0, 0)},
+		{"never: February 30", "0 0 30 2 *", from, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.spec, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEvery(t *testing.T) {
+	s, err := Parse("@every 90m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+	if got, want := s.Next(from), from.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+
+	for _, spec := range []string{"@every 500ms", "@every soon", "0 * * *", "0 0 32 * *", "@fortnightly"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) 에 오류가 없음", spec)
+		}
+	}
+}
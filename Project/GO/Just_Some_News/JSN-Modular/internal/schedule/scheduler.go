@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Job 은 일정에 따라 실행할 작업입니다.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func()
+	// Immediate 면 Run 을 시작하자마자 일정과 관계없이 한 번 실행합니다.
+	Immediate bool
+}
+
+// Scheduler 는 작업을 일정대로 실행합니다. 작업은 한 번에 하나씩만 실행하며,
+// 같은 작업이 실행 중이거나 대기 중일 때 다시 도래한 실행은 건너뜁니다.
+type Scheduler struct {
+	jobs   []Job
+	jitter time.Duration
+
+	mu      sync.Mutex
+	pending map[string]bool // 실행 중이거나 대기 중인 작업
+	queue   chan Job
+}
+
+// New 는 실행 시각마다 [0, jitter) 범위의 무작위 지연을 더하는 스케줄러를 만듭니다.
+func New(jitter time.Duration) *Scheduler {
+	return &Scheduler{jitter: jitter, pending: make(map[string]bool)}
+}
+
+// Add 는 작업을 등록합니다. Run 전에 호출해야 합니다.
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Run 은 ctx 가 끝날 때까지 작업을 실행합니다. 종료 시 새 실행은 시작하지 않고,
+// 진행 중인 작업이 끝나기를 기다린 뒤 반환합니다.
+func (s *Scheduler) Run(ctx context.Context) {
+	// 작업마다 대기 중인 실행은 최대 하나이므로 대기열이 막히지 않음
+	s.queue = make(chan Job, len(s.jobs))
+
+	var timers sync.WaitGroup
+	for _, job := range s.jobs {
+		if job.Immediate {
+			s.enqueue(job)
+		}
+		timers.Go(func() { s.tick(ctx, job) })
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			timers.Wait()
+			return
+		case job := <-s.queue:
+			if ctx.Err() != nil {
+				continue
+			}
+			job.Run()
+			s.mu.Lock()
+			delete(s.pending, job.Name)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// tick 은 작업의 다음 실행 시각까지 기다렸다가 대기열에 넣기를 반복합니다.
+func (s *Scheduler) tick(ctx context.Context, job Job) {
+	next := job.Schedule.Next(time.Now())
+	for !next.IsZero() {
+		at := next
+		if s.jitter > 0 {
+			at = at.Add(rand.N(s.jitter))
+		}
+		log.Printf(">>> [%s] 다음 실행 예정: %s", job.Name, at.Local().Format(time.DateTime))
+
+		t := time.NewTimer(time.Until(at))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		s.enqueue(job)
+		// 지터와 실행 시간 때문에 밀리지 않도록 예정 시각 기준으로 다음 시각 계산
+		next = job.Schedule.Next(next)
+		if now := time.Now(); next.Before(now) {
+			next = job.Schedule.Next(now)
+		}
+	}
+	log.Printf(">>> [%s] 더 이상 실행할 시각이 없음", job.Name)
+}
+
+func (s *Scheduler) enqueue(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending[job.Name] {
+		log.Printf(">>> [%s] 이전 실행이 아직 끝나지 않아 이번 실행은 건너뜀", job.Name)
+		return
+	}
+	s.pending[job.Name] = true
+	s.queue <- job
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// BM25 매개변수. SQL 백엔드도 같은 값으로 점수를 계산합니다.
+const (
+	K1 = 1.2
+	B  = 0.75
+)
+
+// IDF 는 문서 n 개 중 df 개에 나온 색인어의 BM25 역문서 빈도입니다.
+func IDF(n, df int) float64 {
+	return math.Log(1 + (float64(n)-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+// Hit 는 색인 검색 결과 하나입니다.
+type Hit struct {
+	ID    int64
+	Score float64
+}
+
+// Index 는 메모리 역색인입니다. 동시 사용은 호출자가 잠가야 합니다.
+type Index struct {
+	postings map[string]map[int64]int // 색인어 → 기사 id → 가중 빈도
+	docs     map[int64]indexDoc
+	total    int // 가중 길이 합
+}
+
+type indexDoc struct {
+	terms  []string
+	length int
+}
+
+// NewIndex 는 빈 역색인을 만듭니다.
+func NewIndex() *Index {
+	return &Index{postings: make(map[string]map[int64]int), docs: make(map[int64]indexDoc)}
+}
+
+// Add 는 기사를 색인합니다. 이미 있는 id 면 새 내용으로 바꿉니다.
+func (ix *Index) Add(id int64, title, text string) {
+	ix.Remove(id)
+	tf, length := Terms(title, text)
+	doc := indexDoc{terms: make([]string, 0, len(tf)), length: length}
+	for t, n := range tf {
+		p, ok := ix.postings[t]
+		if !ok {
+			p = make(map[int64]int)
+			ix.postings[t] = p
+		}
+		p[id] = n
+		doc.terms = append(doc.terms, t)
+	}
+	ix.docs[id] = doc
+	ix.total += length
+}
+
+// Remove 는 기사를 색인에서 뺍니다.
+func (ix *Index) Remove(id int64) {
+	doc, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	for _, t := range doc.terms {
+		delete(ix.postings[t], id)
+		if len(ix.postings[t]) == 0 {
+			delete(ix.postings, t)
+		}
+	}
+	delete(ix.docs, id)
+	ix.total -= doc.length
+}
+
+// Search 는 q 의 색인어를 모두 가진 기사 중 keep 이 true 인 것을 BM25 점수 내림차순으로 반환합니다.
+func (ix *Index) Search(q Query, keep func(id int64) bool) []Hit {
+	terms := q.Terms()
+	lists := make([]map[int64]int, len(terms))
+	for i, t := range terms {
+		if lists[i] = ix.postings[t]; len(lists[i]) == 0 {
+			return nil
+		}
+	}
+	// 가장 짧은 목록을 기준으로 교집합
+	slices.SortFunc(lists, func(a, b map[int64]int) int { return cmp.Compare(len(a), len(b)) })
+
+	n := len(ix.docs)
+	avg := float64(ix.total) / float64(n)
+	var hits []Hit
+	for id := range lists[0] {
+		score := 0.0
+		for _, p := range lists {
+			tf, ok := p[id]
+			if !ok {
+				score = -1
+				break
+			}
+			score += IDF(n, len(p)) * Score(tf, ix.docs[id].length, avg)
+		}
+		if score >= 0 && keep(id) {
+			hits = append(hits, Hit{ID: id, Score: score})
+		}
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return hits
+}
+
+// Score 는 IDF 를 곱하기 전의 BM25 빈도 항입니다.
+func Score(tf, length int, avgLength float64) float64 {
+	f := float64(tf)
+	return f * (K1 + 1) / (f + K1*(1-B+B*float64(length)/avgLength))
+}
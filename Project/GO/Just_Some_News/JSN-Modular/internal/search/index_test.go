@@ -0,0 +1,52 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIndexSearchBM25Order(t *testing.T) {
+	ix := NewIndex()
+	ix.Add(1, "보안 업데이트 안내", "랜섬웨어 피해를 막으려면 업데이트를 적용해야 한다")
+	ix.Add(2, "랜섬웨어 조직 검거", "경찰이 랜섬웨어 유포 조직을 검거했다")
+	ix.Add(3, "주간 보안 동향", "이번 주에는 피싱 문자, 악성 앱 유포, 랜섬웨어, 공급망 공격, 계정 탈취, 개인정보 유출, 웹사이트 변조, 디도스 공격 등 여러 사고가 있었고 보안 업체들은 다양한 대응 방안을 발표했다")
+	ix.Add(4, "피싱 주의", "택배 문자를 사칭한 피싱이 늘었다")
+
+	q, err := ParseQuery("랜섬웨어")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := func(int64) bool { return true }
+	hits := ix.Search(q, all)
+
+	// 제목에 나온 기사 > 짧은 설명에 나온 기사 > 긴 설명에 나온 기사, 없는 기사는 제외
+	var ids []int64
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	if want := []int64{2, 1, 3}; !slices.Equal(ids, want) {
+		t.Fatalf("순서 = %v, want %v", ids, want)
+	}
+	for i := 1; i < len(hits); i++ {
+		if hits[i].Score > hits[i-1].Score {
+			t.Errorf("점수가 내림차순이 아님: %v", hits)
+		}
+	}
+
+	// 모든 검색어를 포함한 기사만, keep 이 거른 기사는 제외
+	q, _ = ParseQuery("랜섬웨어 검거")
+	if hits := ix.Search(q, all); len(hits) != 1 || hits[0].ID != 2 {
+		t.Errorf("AND 검색 = %v, want [2]", hits)
+	}
+	if hits := ix.Search(q, func(id int64) bool { return id != 2 }); len(hits) != 0 {
+		t.Errorf("keep 으로 거른 검색 = %v, want 없음", hits)
+	}
+
+	// 다시 색인하거나 빼면 이전 내용으로는 찾지 않음
+	ix.Add(2, "경찰 발표", "유포 조직 검거")
+	ix.Remove(3)
+	q, _ = ParseQuery("랜섬웨어")
+	if hits := ix.Search(q, all); len(hits) != 1 || hits[0].ID != 1 {
+		t.Errorf("재색인 후 검색 = %v, want [1]", hits)
+	}
+}
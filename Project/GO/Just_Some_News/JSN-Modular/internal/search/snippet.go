@@ -0,0 +1,90 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Segment 는 강조 표시 조각입니다. Match 이면 검색어와 일치한 부분입니다.
+type Segment struct {
+	Text  string
+	Match bool
+}
+
+// Fragment 는 검색어를 강조할 수 있게 나눈 텍스트입니다.
+type Fragment []Segment
+
+// Highlight 는 text 에서 검색어가 나온 부분을 나눕니다. width 가 0 보다 크면 첫 일치 부근
+// width 글자만 남기고 잘린 쪽에 "…" 를 붙입니다 (스니펫). 스니펫은 공백을 하나로 합쳐 한 줄로 만듭니다.
+func Highlight(text string, q Query, width int) Fragment {
+	if width > 0 {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// 일치 구간 표시 (겹치는 구간은 합쳐짐)
+	match := make([]bool, len(runes))
+	first := -1
+	for _, w := range q.Words {
+		word := []rune(w.Text)
+		for i := 0; i+len(word) <= len(lower); i++ {
+			if string(lower[i:i+len(word)]) != w.Text {
+				continue
+			}
+			for j := i; j < i+len(word); j++ {
+				match[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(runes)
+	if width > 0 && len(runes) > width {
+		// 첫 일치 앞쪽 문맥을 1/4 정도 보여 줌
+		start = max(0, first-width/4)
+		end = min(len(runes), start+width)
+		start = max(0, end-width)
+	}
+
+	var f Fragment
+	if start > 0 {
+		f = append(f, Segment{Text: "…"})
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && match[j] == match[i] {
+			j++
+		}
+		f = append(f, Segment{Text: string(runes[i:j]), Match: match[i]})
+		i = j
+	}
+	if end < len(runes) {
+		f = append(f, Segment{Text: "…"})
+	}
+	return f
+}
+
+// Format 은 일치한 부분을 open, close 로 감싸 이어 붙입니다. escape 가 있으면 각 조각에 먼저 적용합니다.
+func (f Fragment) Format(open, close string, escape func(string) string) string {
+	var sb strings.Builder
+	for _, s := range f {
+		text := s.Text
+		if escape != nil {
+			text = escape(text)
+		}
+		if s.Match {
+			sb.WriteString(open + text + close)
+		} else {
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+func (f Fragment) String() string { return f.Format("", "", nil) }
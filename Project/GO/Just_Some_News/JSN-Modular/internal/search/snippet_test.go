@@ -0,0 +1,58 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	q, err := ParseQuery("패치 cve-2026-1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"whole text", "긴급 패치: CVE-2026-1234 수정", 0, "긴급 [패치]: [CVE-2026-1234] 수정"},
+		{"case kept, whitespace collapsed", "PATCH  패치\n\n적용", 40, "PATCH [패치] 적용"},
+		{
+			"match near the end",
+			strings.Repeat("가", 30) + " 끝에 패치 공개",
+			12,
+			"…가가가 끝에 [패치] 공개",
+		},
+		{
+			"match in the middle",
+			strings.Repeat("가", 20) + "패치" + strings.Repeat("나", 20),
+			10,
+			"…가가[패치]나나나나나나…",
+		},
+		{
+			// 제목에만 일치하면 설명에는 표시할 부분이 없어 앞부분을 보여 줌
+			"title-only match",
+			"설명에는 검색어가 전혀 나오지 않는 긴 문장입니다",
+			10,
+			"설명에는 검색어가 …",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.text, q, tt.width).Format("[", "]", nil); got != tt.want {
+				t.Errorf("Highlight = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFragmentFormatEscape(t *testing.T) {
+	q, _ := ParseQuery("패치")
+	got := Highlight("<b>패치</b>", q, 0).Format("<mark>", "</mark>", func(s string) string {
+		return strings.NewReplacer("<", "&lt;", ">", "&gt;").Replace(s)
+	})
+	if want := "&lt;b&gt;<mark>패치</mark>&lt;/b&gt;"; got != want {
+		t.Errorf("Format = %q, want %q", got, want)
+	}
+}
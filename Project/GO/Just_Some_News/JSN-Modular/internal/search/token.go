@@ -0,0 +1,141 @@
+// Package search tokenizes Korean and Latin text for full-text search and ranks matches with BM25.
+package search
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// 한국어는 조사가 붙고 띄어쓰기가 일정하지 않아 단어 대신 글자 2-gram 으로 색인합니다.
+// 영문/숫자는 단어 단위입니다.
+const (
+	// titleWeight 는 제목에 나온 검색어의 가중치입니다 (설명은 1).
+	titleWeight = 3
+	// maxTermRunes 는 색인어 최대 길이입니다. 더 긴 영문/숫자 단어는 잘라서 씁니다.
+	maxTermRunes = 32
+)
+
+// ErrShortQuery 는 색인으로 찾을 수 있는 검색어가 하나도 없음을 나타냅니다.
+var ErrShortQuery = errors.New("검색어가 너무 짧음 (한글/한자는 두 글자 이상)")
+
+// Tokens 는 text 의 색인어를 나온 순서대로 반환합니다 (중복 포함).
+//
+//	"랜섬웨어 공격"      → 랜섬, 섬웨, 웨어, 공격
+//	"CVE-2026-1234 패치" → cve, 2026, 1234, 패치
+//
+// 한 글자짜리 한글/한자 덩어리는 색인하지 않습니다.
+func Tokens(text string) []string {
+	var out []string
+	var run []rune
+	cjk := false
+	flush := func() {
+		switch {
+		case len(run) == 0:
+		case cjk:
+			for i := 0; i+2 <= len(run); i++ {
+				out = append(out, string(run[i:i+2]))
+			}
+		default:
+			out = append(out, string(run[:min(len(run), maxTermRunes)]))
+		}
+		run = run[:0]
+	}
+	for _, r := range text {
+		r = unicode.ToLower(r)
+		switch {
+		case isCJK(r):
+			if !cjk {
+				flush()
+			}
+			cjk = true
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if cjk {
+				flush()
+			}
+			cjk = false
+			run = append(run, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Hangul, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// Terms 는 기사 하나의 색인어별 가중 빈도와 가중 길이를 계산합니다.
+func Terms(title, text string) (tf map[string]int, length int) {
+	tf = make(map[string]int)
+	for _, t := range Tokens(title) {
+		tf[t] += titleWeight
+		length += titleWeight
+	}
+	for _, t := range Tokens(text) {
+		tf[t]++
+		length++
+	}
+	return tf, length
+}
+
+// Word 는 공백으로 구분한 검색어 하나입니다.
+type Word struct {
+	Text  string   // 소문자로 바꾼 원문 (부분 문자열 확인용)
+	Terms []string // 색인어. 한 글자 한글처럼 색인할 수 없으면 비어 있음
+}
+
+// Query 는 해석한 검색식입니다. 모든 검색어를 포함한 기사만 찾습니다.
+type Query struct {
+	Words []Word
+}
+
+// ParseQuery 는 공백으로 구분한 검색어를 해석합니다. 색인어가 하나도 없으면 ErrShortQuery 입니다.
+func ParseQuery(s string) (Query, error) {
+	var q Query
+	for f := range strings.FieldsSeq(strings.ToLower(s)) {
+		if slices.ContainsFunc(q.Words, func(w Word) bool { return w.Text == f }) {
+			continue
+		}
+		w := Word{Text: f}
+		for _, t := range Tokens(f) {
+			if !slices.Contains(w.Terms, t) {
+				w.Terms = append(w.Terms, t)
+			}
+		}
+		q.Words = append(q.Words, w)
+	}
+	if len(q.Terms()) == 0 {
+		return Query{}, ErrShortQuery
+	}
+	return q, nil
+}
+
+// Terms 는 모든 검색어의 색인어를 중복 없이 반환합니다.
+func (q Query) Terms() []string {
+	var out []string
+	for _, w := range q.Words {
+		for _, t := range w.Terms {
+			if !slices.Contains(out, t) {
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// Matches 는 제목이나 설명에 모든 검색어가 부분 문자열로 들어 있는지 확인합니다.
+// 2-gram 이 모두 있어도 서로 떨어져 있을 수 있어 색인 후보를 이것으로 다시 거릅니다.
+func (q Query) Matches(title, text string) bool {
+	title, text = strings.ToLower(title), strings.ToLower(text)
+	for _, w := range q.Words {
+		if !strings.Contains(title, w.Text) && !strings.Contains(text, w.Text) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"랜섬웨어 공격", []string{"랜섬", "섬웨", "웨어", "공격"}},
+		{"CVE-2026-1234 패치", []string{"cve", "2026", "1234", "패치"}},
+		{"MS오피스 취약점", []string{"ms", "오피", "피스", "취약", "약점"}},
+		{"북 해킹", []string{"해킹"}},
+		{"Log4j, 'Log4Shell'!", []string{"log4j", "log4shell"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := Tokens(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("Tokens(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTermsTitleWeight(t *testing.T) {
+	tf, length := Terms("패치 공개", "긴급 패치")
+	if tf["패치"] != titleWeight+1 || tf["공개"] != titleWeight || tf["긴급"] != 1 {
+		t.Errorf("Terms 빈도 = %v", tf)
+	}
+	if length != 2*titleWeight+2 {
+		t.Errorf("Terms 길이 = %d, want %d", length, 2*titleWeight+2)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery("랜섬웨어  CVE-2026-1234 랜섬웨어")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Words) != 2 {
+		t.Fatalf("검색어 %d개, want 2 (중복 제거)", len(q.Words))
+	}
+	want := []string{"랜섬", "섬웨", "웨어", "cve", "2026", "1234"}
+	if got := q.Terms(); !slices.Equal(got, want) {
+		t.Errorf("Terms = %q, want %q", got, want)
+	}
+
+	for _, s := range []string{"북", "북 한", "  ", "- !"} {
+		if _, err := ParseQuery(s); !errors.Is(err, ErrShortQuery) {
+			t.Errorf("ParseQuery(%q) 오류 = %v, want ErrShortQuery", s, err)
+		}
+	}
+	// 한 글자 검색어는 다른 검색어의 색인어로 찾고 Matches 로 거름
+	q, err = ParseQuery("북 해킹")
+	if err != nil {
+		t.Fatalf("ParseQuery(\"북 해킹\"): %v", err)
+	}
+	if !q.Matches("북한 해킹 조직", "") || q.Matches("남한 해킹 조직", "") {
+		t.Error("한 글자 검색어가 Matches 에서 걸러지지 않음")
+	}
+}
@@ -0,0 +1,101 @@
+// Package severity estimates how serious a security news article is from its title and text.
+package severity
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Level 은 기사 심각도입니다. 값이 클수록 심각합니다.
+type Level int
+
+const (
+	Low Level = iota
+	Medium
+	High
+	Critical
+)
+
+var names = [...]string{"low", "medium", "high", "critical"}
+
+func (l Level) String() string {
+	if l < Low || l > Critical {
+		return "level(" + strconv.Itoa(int(l)) + ")"
+	}
+	return names[l]
+}
+
+// Parse 는 "low", "medium", "high", "critical" 을 해석합니다 (대소문자 무시).
+func Parse(s string) (Level, error) {
+	for i, n := range names {
+		if strings.EqualFold(s, n) {
+			return Level(i), nil
+		}
+	}
+	return Low, fmt.Errorf("알 수 없는 심각도 %q (low, medium, high, critical)", s)
+}
+
+// 심각도별 단서. 영문은 단어 경계로, 한글은 부분 문자열로 찾습니다 (조사가 붙으므로).
+var signals = []struct {
+	level Level
+	words []string
+}{
+	{Critical, []string{"제로데이", "zero-day", "0-day", "actively exploited", "in the wild", "악용 중", "실제 공격에 악용", "긴급 패치", "긴급 업데이트", "긴급 보안"}},
+	{High, []string{"랜섬웨어", "ransomware", "원격 코드 실행", "remote code execution", "rce", "백도어", "backdoor", "공급망 공격", "supply chain attack", "정보 유출", "개인정보 유출", "data breach", "해킹"}},
+	{Medium, []string{"취약점", "vulnerability", "cve", "보안 업데이트", "패치", "patch", "악성코드", "malware", "피싱", "phishing", "디도스", "ddos"}},
+}
+
+var patterns = func() []*regexp.Regexp {
+	out := make([]*regexp.Regexp, len(signals))
+	for i, s := range signals {
+		alts := make([]string, len(s.words))
+		for j, w := range s.words {
+			alts[j] = regexp.QuoteMeta(w)
+			if isASCII(w) {
+				alts[j] = `\b` + alts[j] + `\b`
+			}
+		}
+		out[i] = regexp.MustCompile(`(?i)` + strings.Join(alts, "|"))
+	}
+	return out
+}()
+
+// cvss 는 "CVSS 9.8", "CVSS v3.1 점수: 7.5", "CVSS score of 8.1" 같은 점수 표기입니다.
+var cvss = regexp.MustCompile(`(?i)cvss(?:\s*v?[234](?:\.\d)?)?(?:\s*(?:base\s*)?(?:score|점수))?(?:\s*(?:of|는|은|:|：))?\s*(\d{1,2}(?:\.\d)?)`)
+
+// Classify 는 제목과 본문 평문의 CVSS 점수와 단서 단어로 심각도를 추정합니다. 단서가 없으면 Low 입니다.
+func Classify(title, text string) Level {
+	s := title + "\n" + text
+	level := Low
+	for _, m := range cvss.FindAllStringSubmatch(s, -1) {
+		score, err := strconv.ParseFloat(m[1], 64)
+		if err != nil || score > 10 {
+			continue
+		}
+		switch {
+		case score >= 9:
+			level = max(level, Critical)
+		case score >= 7:
+			level = max(level, High)
+		case score >= 4:
+			level = max(level, Medium)
+		}
+	}
+	for i, p := range patterns {
+		if signals[i].level > level && p.MatchString(s) {
+			level = signals[i].level
+		}
+	}
+	return level
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
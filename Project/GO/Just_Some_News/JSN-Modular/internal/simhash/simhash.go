@@ -0,0 +1,73 @@
+// Package simhash computes 64-bit SimHash fingerprints for near-duplicate text detection.
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// shingleSize 는 특징(feature)으로 쓰는 글자 n-gram 길이입니다.
+// 한국어는 띄어쓰기가 기사마다 달라 단어보다 글자 n-gram 이 안정적입니다.
+const shingleSize = 3
+
+// Fingerprint 는 텍스트를 정규화한 뒤 글자 n-gram 을 특징으로 SimHash 를 계산합니다.
+// 비슷한 텍스트일수록 해밍 거리가 작은 값이 나옵니다. 정규화 후 남는 글자가 없으면 0 입니다.
+func Fingerprint(text string) uint64 {
+	runes := normalize(text)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	add := func(feature string) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for i := range 64 {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	if len(runes) < shingleSize {
+		add(string(runes))
+	}
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		add(string(runes[i : i+shingleSize]))
+	}
+
+	var fp uint64
+	for i, w := range weights {
+		if w > 0 {
+			fp |= 1 << uint(i)
+		}
+	}
+	return fp
+}
+
+// Distance 는 두 지문의 해밍 거리(다른 비트 수)입니다.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// normalize 는 소문자로 바꾸고 글자/숫자만 남긴 뒤 공백을 하나로 합칩니다.
+func normalize(text string) []rune {
+	var sb strings.Builder
+	space := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			sb.WriteRune(r)
+			space = false
+		case !space && sb.Len() > 0:
+			sb.WriteByte(' ')
+			space = true
+		}
+	}
+	return []rune(strings.TrimSpace(sb.String()))
+}
@@ -0,0 +1,73 @@
+package simhash
+
+import "testing"
+
+// threshold 는 config.Default 의 cluster.threshold 입니다.
+const threshold = 10
+
+func TestNearDuplicateTitles(t *testing.T) {
+	const base = "북한 해킹조직 라자루스, 국내 방산업체 공격 정황 포착"
+	tests := []struct {
+		name  string
+		a, b  string
+		close bool
+	}{
+		{"punctuation only", base, "북한 해킹조직 '라자루스', 국내 방산업체 공격 정황 포착", true},
+		{"prefix tag", base, "[단독] 북한 해킹조직 라자루스, 국내 방산업체 공격 정황 포착", true},
+		{"spacing differs", base, "북한 해킹 조직 라자루스 국내 방산 업체 공격 정황 포착", true},
+		{"english case and digits", "Microsoft fixes four zero-days in October Patch Tuesday",
+			"Microsoft Fixes 4 Zero-Days in October 2026 Patch Tuesday", true},
+		{"unrelated korean", base, "마이크로소프트, 10월 정기 보안 업데이트 발표… 제로데이 4건 수정", false},
+		{"shares one word", base, "랜섬웨어 조직 검거, 암호화폐 20억 원 압수", false},
+		{"unrelated english", "Microsoft fixes four zero-days in October Patch Tuesday",
+			"Ransomware gang arrested in Europe", false},
+	}
+	for _, tt := range tests {
+		d := Distance(Fingerprint(tt.a), Fingerprint(tt.b))
+		if (d <= threshold) != tt.close {
+			t.Errorf("%s: 거리 %d, want 임계치 %d 이하 %v", tt.name, d, threshold, tt.close)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	if fp := Fingerprint("  ...!?  "); fp != 0 {
+		t.Errorf("글자 없는 텍스트 = %x, want 0", fp)
+	}
+	if Fingerprint("보안") == 0 {
+		t.Error("shingle 보다 짧은 텍스트의 지문이 0")
+	}
+	// 대소문자, 구두점, 연속 공백은 지문에 영향 없음
+	if a, b := Fingerprint("Patch Tuesday: 4 zero-days"), Fingerprint("patch   tuesday 4 zero days"); a != b {
+		t.Errorf("정규화 후 같은 텍스트의 지문이 다름: %x, %x", a, b)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{0, 0, 0},
+		{0b1011, 0b0001, 2},
+		{0, ^uint64(0), 64},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%b, %b) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"[단독] 북한, '라자루스'":        "단독 북한 라자루스",
+		"  Zero-Day\t CVE-2026 ": "zero day cve 2026",
+		"…":                      "",
+	}
+	for in, want := range tests {
+		if got := string(normalize(in)); got != want {
+			t.Errorf("normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 알림 전송 상태. pending 은 전송 중이며, 프로세스가 전송 중에 죽어도 다시 보내지 않습니다 (최대 한 번).
+const (
+	AlertPending = "pending"
+	AlertSent    = "sent"
+	AlertFailed  = "failed"
+)
+
+// AlertDelivery 는 기사 하나를 웹훅 하나로 보낸 알림 기록입니다.
+type AlertDelivery struct {
+	ArticleID int64
+	Webhook   string
+	Rules     []string // 일치한 규칙 이름
+	Status    string
+	Attempts  int // 지금까지의 전송 시도 횟수 (재시도 포함)
+	LastError string
+	CreatedAt time.Time
+}
+
+// joinRules 는 규칙 이름을 rules 컬럼(VARCHAR(512))에 맞게 쉼표로 잇습니다.
+func joinRules(rules []string) string {
+	s := []rune(strings.Join(rules, ","))
+	if len(s) > 512 {
+		s = s[:512]
+	}
+	return string(s)
+}
+
+func splitRules(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func (s *sqlStore) ClaimAlert(ctx context.Context, articleID int64, webhook string, rules []string) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		s.d.insertIgnore+" INTO alert_deliveries (article_id, webhook, rules, status, created_at) VALUES (?, ?, ?, ?, ?)",
+		articleID, webhook, joinRules(rules), AlertPending, time.Now().UTC(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("알림 기록 실패: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (s *sqlStore) FailedAlerts(ctx context.Context, since time.Time) ([]AlertDelivery, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT article_id, webhook, rules, status, attempts, last_error, created_at FROM alert_deliveries
+		WHERE status = ? AND created_at >= ? ORDER BY created_at, article_id`,
+		AlertFailed, since.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("알림 기록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var out []AlertDelivery
+	for rows.Next() {
+		var d AlertDelivery
+		var rules string
+		if err := rows.Scan(&d.ArticleID, &d.Webhook, &rules, &d.Status, &d.Attempts, &d.LastError, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("알림 기록 조회 실패: %w", err)
+		}
+		d.Rules = splitRules(rules)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqlStore) ReclaimAlert(ctx context.Context, articleID int64, webhook string) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		"UPDATE alert_deliveries SET status = ? WHERE article_id = ? AND webhook = ? AND status = ?",
+		AlertPending, articleID, webhook, AlertFailed,
+	)
+	if err != nil {
+		return false, fmt.Errorf("알림 기록 갱신 실패: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (s *sqlStore) FinishAlert(ctx context.Context, articleID int64, webhook string, attempts int, cause error) error {
+	status, msg := AlertSent, ""
+	var sentAt any
+	if cause != nil {
+		status, msg = AlertFailed, errorMessage(cause)
+	} else {
+		sentAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE alert_deliveries SET status = ?, attempts = attempts + ?, last_error = ?, sent_at = ?
+		WHERE article_id = ? AND webhook = ?`,
+		status, attempts, msg, sentAt, articleID, webhook,
+	)
+	if err != nil {
+		return fmt.Errorf("알림 기록 갱신 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,419 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"jsn-modular/internal/search"
+	"jsn-modular/internal/simhash"
+)
+
+// Memory 는 프로세스 메모리에만 두는 Store 입니다. 단위 테스트와 DB 없는 시험 실행용입니다.
+type Memory struct {
+	opts Options
+
+	mu        sync.Mutex
+	articles  map[int64]*Article
+	canonical map[string]int64
+	links     map[string]int64
+	clusters  []memCluster
+	feeds     map[string]memFeed
+	alerts    map[memAlertKey]*AlertDelivery
+	digests   map[string]time.Time
+	index     *search.Index
+	nextID    int64
+}
+
+type memCluster struct {
+	ID       int64
+	SimHash  uint64
+	LastSeen time.Time
+}
+
+type memAlertKey struct {
+	ArticleID int64
+	Webhook   string
+}
+
+type memFeed struct {
+	URL string
+	FeedState
+}
+
+// NewMemory 는 빈 메모리 저장소를 만듭니다.
+func NewMemory(opts Options) *Memory {
+	return &Memory{
+		opts:      opts,
+		articles:  make(map[int64]*Article),
+		canonical: make(map[string]int64),
+		links:     make(map[string]int64),
+		feeds:     make(map[string]memFeed),
+		alerts:    make(map[memAlertKey]*AlertDelivery),
+		digests:   make(map[string]time.Time),
+		index:     search.NewIndex(),
+	}
+}
+
+func (m *Memory) Close() error { return nil }
+
+func (m *Memory) Exists(_ context.Context, canonical string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.canonical[canonical]
+	return ok, nil
+}
+
+func (m *Memory) Insert(_ context.Context, a Article) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.find(a); ok {
+		return 0, fmt.Errorf("기사 삽입 실패: 이미 저장된 기사: %s", a.Canonical)
+	}
+	return m.insert(a), nil
+}
+
+func (m *Memory) find(a Article) (int64, bool) {
+	if id, ok := m.canonical[a.Canonical]; ok {
+		return id, true
+	}
+	id, ok := m.links[a.Link]
+	return id, ok
+}
+
+func (m *Memory) insert(a Article) int64 {
+	m.nextID++
+	a.ID = m.nextID
+	a.PubDate = a.PubDate.UTC()
+	a.CollectedAt = time.Now().UTC()
+	m.articles[a.ID] = &a
+	m.canonical[a.Canonical] = a.ID
+	m.links[a.Link] = a.ID
+	m.index.Add(a.ID, a.Title, a.DescriptionText)
+	return a.ID
+}
+
+// UpsertBatch 는 sqlStore 와 같은 규칙으로 신규/갱신/변경 없음을 나눕니다.
+func (m *Memory) UpsertBatch(_ context.Context, articles []Article) (UpsertResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res UpsertResult
+	for _, a := range articles {
+		id, ok := m.find(a)
+		if !ok {
+			id = m.insert(a)
+			clusterID, joined := m.assign(id, a.SimHash)
+			res.Inserted++
+			res.New = append(res.New, NewArticle{ID: id, Title: a.Title, Link: a.Link, ClusterID: clusterID, Joined: joined})
+			continue
+		}
+
+		old := m.articles[id]
+		if old.Title == a.Title && old.Description == a.Description &&
+			(a.DateGuessed || old.PubDate.Equal(a.PubDate)) {
+			res.Unchanged++
+			continue
+		}
+		delete(m.links, old.Link)
+		delete(m.canonical, old.Canonical)
+		old.Title, old.Link, old.Canonical = a.Title, a.Link, a.Canonical
+		old.Description, old.DescriptionText, old.SimHash = a.Description, a.DescriptionText, a.SimHash
+		if !a.DateGuessed {
+			old.PubDate, old.DateGuessed = a.PubDate.UTC(), false
+		}
+		m.links[old.Link], m.canonical[old.Canonical] = id, id
+		m.index.Add(id, old.Title, old.DescriptionText)
+		if old.ClusterID == 0 {
+			m.assign(id, old.SimHash)
+		}
+		res.Updated++
+	}
+	return res, nil
+}
+
+// assign 은 ClusterWindow 안에 갱신된 묶음 중 가장 가까운 곳에 기사를 넣습니다.
+// sqlStore 와 같이 지문이 0 이면 배정하지 않습니다.
+func (m *Memory) assign(articleID int64, fp uint64) (int64, bool) {
+	if fp == 0 {
+		return 0, false
+	}
+	now := time.Now().UTC()
+	best, bestDist := -1, m.opts.ClusterThreshold+1
+	for i, c := range m.clusters {
+		if now.Sub(c.LastSeen) > m.opts.ClusterWindow {
+			continue
+		}
+		if d := simhash.Distance(c.SimHash, fp); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	joined := best >= 0
+	if joined {
+		m.clusters[best].LastSeen = now
+	} else {
+		m.clusters = append(m.clusters, memCluster{ID: int64(len(m.clusters) + 1), SimHash: fp, LastSeen: now})
+		best = len(m.clusters) - 1
+	}
+	m.articles[articleID].ClusterID = m.clusters[best].ID
+	return m.clusters[best].ID, joined
+}
+
+func (m *Memory) UpdateContent(_ context.Context, id int64, c Content) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if a, ok := m.articles[id]; ok {
+		a.Content, a.WordCount, a.ContentStatus = c.Text, c.WordCount, c.Status
+	}
+	return nil
+}
+
+func (m *Memory) Get(_ context.Context, id int64) (Article, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.articles[id]
+	if !ok {
+		return Article{}, ErrNotFound
+	}
+	return *a, nil
+}
+
+func (m *Memory) Query(_ context.Context, q Query) ([]Article, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keyword := strings.ToLower(q.Keyword)
+	var out []Article
+	for _, a := range m.articles {
+		switch {
+		case q.Source != "" && a.Source != q.Source:
+		case !q.Since.IsZero() && a.PubDate.Before(q.Since):
+		case !q.Until.IsZero() && !a.PubDate.Before(q.Until):
+		case !q.CollectedSince.IsZero() && a.CollectedAt.Before(q.CollectedSince):
+		case !q.CollectedUntil.IsZero() && !a.CollectedAt.Before(q.CollectedUntil):
+		case keyword != "" && !strings.Contains(strings.ToLower(a.Title), keyword) &&
+			!strings.Contains(strings.ToLower(a.DescriptionText), keyword):
+		case q.Before != nil && !a.PubDate.Before(q.Before.PubDate) &&
+			(!a.PubDate.Equal(q.Before.PubDate) || a.ID >= q.Before.ID):
+		default:
+			out = append(out, *a)
+		}
+	}
+	slices.SortFunc(out, func(x, y Article) int {
+		if c := y.PubDate.Compare(x.PubDate); c != 0 {
+			return c
+		}
+		return cmp.Compare(y.ID, x.ID)
+	})
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+func (m *Memory) Search(_ context.Context, q SearchQuery) ([]SearchHit, error) {
+	sq, err := search.ParseQuery(q.Text)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hits := m.index.Search(sq, func(id int64) bool {
+		a := m.articles[id]
+		switch {
+		case q.Source != "" && a.Source != q.Source:
+		case !q.Since.IsZero() && a.PubDate.Before(q.Since):
+		case !q.Until.IsZero() && !a.PubDate.Before(q.Until):
+		default:
+			return sq.Matches(a.Title, a.DescriptionText)
+		}
+		return false
+	})
+	out := make([]SearchHit, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, SearchHit{Article: *m.articles[h.ID], Score: h.Score})
+	}
+	slices.SortStableFunc(out, func(x, y SearchHit) int {
+		if c := cmp.Compare(y.Score, x.Score); c != 0 {
+			return c
+		}
+		if c := y.PubDate.Compare(x.PubDate); c != 0 {
+			return c
+		}
+		return cmp.Compare(y.ID, x.ID)
+	})
+	out = out[min(q.Offset, len(out)):]
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+func (m *Memory) Stats(_ context.Context) ([]SourceStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bySource := make(map[string]*SourceStats)
+	for _, a := range m.articles {
+		st, ok := bySource[a.Source]
+		if !ok {
+			st = &SourceStats{Source: a.Source, Oldest: a.PubDate, Newest: a.PubDate}
+			bySource[a.Source] = st
+		}
+		st.Articles++
+		if a.PubDate.Before(st.Oldest) {
+			st.Oldest = a.PubDate
+		}
+		if a.PubDate.After(st.Newest) {
+			st.Newest = a.PubDate
+		}
+		if a.CollectedAt.After(st.LastCollected) {
+			st.LastCollected = a.CollectedAt
+		}
+	}
+	out := make([]SourceStats, 0, len(bySource))
+	for _, st := range bySource {
+		out = append(out, *st)
+	}
+	slices.SortFunc(out, func(x, y SourceStats) int { return cmp.Compare(x.Source, y.Source) })
+	return out, nil
+}
+
+func (m *Memory) Prune(_ context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int64
+	for id, a := range m.articles {
+		if a.PubDate.Before(before) {
+			delete(m.articles, id)
+			delete(m.canonical, a.Canonical)
+			delete(m.links, a.Link)
+			m.index.Remove(id)
+			for key := range m.alerts {
+				if key.ArticleID == id {
+					delete(m.alerts, key)
+				}
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *Memory) LastDigest(_ context.Context, kind string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.digests[kind], nil
+}
+
+func (m *Memory) SaveDigest(_ context.Context, kind string, _, end time.Time, _ int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.digests[kind] = end.UTC()
+	return nil
+}
+
+func (m *Memory) LoadFeedState(_ context.Context, feed, url string) (FeedState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.feeds[feed]
+	if !ok || f.URL != url {
+		return FeedState{}, nil
+	}
+	return f.FeedState, nil
+}
+
+func (m *Memory) SaveFeedState(_ context.Context, feed, url, etag, lastModified string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f := m.feeds[feed]
+	f.URL, f.ETag, f.LastModified, f.Failures = url, etag, lastModified, 0
+	m.feeds[feed] = f
+	return nil
+}
+
+func (m *Memory) MarkFeedAlive(_ context.Context, feed string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if f, ok := m.feeds[feed]; ok {
+		f.Failures = 0
+		m.feeds[feed] = f
+	}
+	return nil
+}
+
+func (m *Memory) RecordFeedFailure(_ context.Context, feed, url string, _ error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.feeds[feed]
+	if !ok {
+		f.URL = url
+	}
+	f.Failures++
+	f.LastFailure = time.Now().UTC()
+	m.feeds[feed] = f
+	return nil
+}
+
+func (m *Memory) ClaimAlert(_ context.Context, articleID int64, webhook string, rules []string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := memAlertKey{articleID, webhook}
+	if _, ok := m.alerts[key]; ok {
+		return false, nil
+	}
+	m.alerts[key] = &AlertDelivery{
+		ArticleID: articleID,
+		Webhook:   webhook,
+		Rules:     slices.Clone(rules),
+		Status:    AlertPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	return true, nil
+}
+
+func (m *Memory) FailedAlerts(_ context.Context, since time.Time) ([]AlertDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []AlertDelivery
+	for _, d := range m.alerts {
+		if d.Status == AlertFailed && !d.CreatedAt.Before(since) {
+			out = append(out, *d)
+		}
+	}
+	slices.SortFunc(out, func(a, b AlertDelivery) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ArticleID, b.ArticleID))
+	})
+	return out, nil
+}
+
+func (m *Memory) ReclaimAlert(_ context.Context, articleID int64, webhook string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.alerts[memAlertKey{articleID, webhook}]
+	if !ok || d.Status != AlertFailed {
+		return false, nil
+	}
+	d.Status = AlertPending
+	return true, nil
+}
+
+func (m *Memory) FinishAlert(_ context.Context, articleID int64, webhook string, attempts int, cause error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.alerts[memAlertKey{articleID, webhook}]
+	if !ok {
+		return nil
+	}
+	d.Attempts += attempts
+	d.Status, d.LastError = AlertSent, ""
+	if cause != nil {
+		d.Status, d.LastError = AlertFailed, errorMessage(cause)
+	}
+	return nil
+}
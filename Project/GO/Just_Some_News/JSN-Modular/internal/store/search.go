@@ -0,0 +1,262 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/internal/search"
+)
+
+// indexBatchSize 는 기동 시 색인하지 않은 기사를 한 트랜잭션에 색인하는 수입니다.
+const indexBatchSize = 500
+
+// termBatchSize 는 다중 행 INSERT 한 번에 넣는 색인어 행 수입니다.
+const termBatchSize = 300
+
+// SearchQuery 는 전문 검색 조건입니다. 결과는 관련도 내림차순입니다.
+type SearchQuery struct {
+	Text   string    // 공백으로 구분한 검색어. 모두 포함한 기사만 찾음
+	Source string    // 출처
+	Since  time.Time // 포함
+	Until  time.Time // 미포함
+	Limit  int
+	Offset int
+}
+
+// SearchHit 는 전문 검색 결과 하나입니다.
+type SearchHit struct {
+	Article
+	Score float64 // 관련도 (백엔드마다 척도가 다르므로 순서 비교에만 사용)
+}
+
+// execer 는 *sql.DB 와 *sql.Tx 의 공통 부분입니다.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// indexArticles 는 기사들의 색인을 새로 씁니다.
+func (s *sqlStore) indexArticles(ctx context.Context, db execer, articles []Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	ids := make([]any, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	for _, table := range []string{"search_terms", "search_docs"} {
+		if _, err := db.ExecContext(ctx,
+			"DELETE FROM "+table+" WHERE article_id IN ("+placeholders(len(ids))+")", ids...,
+		); err != nil {
+			return fmt.Errorf("검색 색인 삭제 실패: %w", err)
+		}
+	}
+
+	var rows []any
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+		n := len(rows) / 3
+		values := strings.TrimSuffix(strings.Repeat("(?, ?, ?), ", n), ", ")
+		_, err := db.ExecContext(ctx, "INSERT INTO search_terms (term, article_id, tf) VALUES "+values, rows...)
+		rows = rows[:0]
+		return err
+	}
+	docs := make([]any, 0, 2*len(articles))
+	for _, a := range articles {
+		tf, length := search.Terms(a.Title, a.DescriptionText)
+		for term, n := range tf {
+			rows = append(rows, term, a.ID, n)
+			if len(rows) == 3*termBatchSize {
+				if err := flush(); err != nil {
+					return fmt.Errorf("검색 색인 쓰기 실패: %w", err)
+				}
+			}
+		}
+		docs = append(docs, a.ID, length)
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("검색 색인 쓰기 실패: %w", err)
+	}
+	values := strings.TrimSuffix(strings.Repeat("(?, ?), ", len(articles)), ", ")
+	if _, err := db.ExecContext(ctx, "INSERT INTO search_docs (article_id, length) VALUES "+values, docs...); err != nil {
+		return fmt.Errorf("검색 색인 쓰기 실패: %w", err)
+	}
+	return nil
+}
+
+// indexMissing 은 색인되지 않은 기사 (색인 테이블을 만들기 전에 수집한 기사 등) 를 색인합니다.
+func (s *sqlStore) indexMissing(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		rows, err := s.db.QueryContext(ctx,
+			"SELECT a.id, a.title, COALESCE(a.description_text, '') FROM security_articles a "+
+				"LEFT JOIN search_docs d ON d.article_id = a.id WHERE d.article_id IS NULL "+
+				"ORDER BY a.id LIMIT "+strconv.Itoa(indexBatchSize),
+		)
+		if err != nil {
+			return total, fmt.Errorf("색인할 기사 조회 실패: %w", err)
+		}
+		var batch []Article
+		for rows.Next() {
+			var a Article
+			if err := rows.Scan(&a.ID, &a.Title, &a.DescriptionText); err != nil {
+				rows.Close()
+				return total, fmt.Errorf("색인할 기사 조회 실패: %w", err)
+			}
+			batch = append(batch, a)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return total, fmt.Errorf("색인할 기사 조회 실패: %w", err)
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return total, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+		}
+		if err := s.indexArticles(ctx, tx, batch); err != nil {
+			_ = tx.Rollback()
+			return total, err
+		}
+		if err := tx.Commit(); err != nil {
+			return total, fmt.Errorf("커밋 실패: %w", err)
+		}
+		total += len(batch)
+	}
+}
+
+// Search 는 제목과 평문 설명을 search_terms 역색인과 BM25 로 전문 검색합니다.
+// MariaDB 에는 한국어용 FULLTEXT 파서(ngram)가 없어 MariaDB, SQLite 모두 이 경로만 씁니다.
+// 검색어에 색인어가 없으면 search.ErrShortQuery 를 반환합니다.
+func (s *sqlStore) Search(ctx context.Context, q SearchQuery) ([]SearchHit, error) {
+	sq, err := search.ParseQuery(q.Text)
+	if err != nil {
+		return nil, err
+	}
+	where, args := filterClauses(q.Source, q.Since, q.Until)
+
+	sub, subArgs, ok, err := s.scoreQuery(ctx, sq)
+	if err != nil || !ok {
+		return nil, err
+	}
+	// 2-gram 은 서로 떨어져 있어도 일치하므로 부분 문자열로 다시 거름
+	for _, w := range sq.Words {
+		where, args = likeClause(where, args, w.Text)
+	}
+	query := "SELECT " + articleSelect + ", m.score FROM (" + sub + ") m JOIN security_articles ON id = m.article_id"
+	args = append(subArgs, args...)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY score DESC, pubDate DESC, id DESC"
+	if q.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(q.Limit)
+		if q.Offset > 0 {
+			query += " OFFSET " + strconv.Itoa(q.Offset)
+		}
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("검색 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var out []SearchHit
+	for rows.Next() {
+		var h SearchHit
+		if h.Article, err = scanArticle(rows, &h.Score); err != nil {
+			return nil, fmt.Errorf("검색 실패: %w", err)
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
+
+// scoreQuery 는 모든 색인어를 가진 기사의 id 와 BM25 점수를 구하는 하위 쿼리를 만듭니다.
+// 문서 빈도는 미리 읽어 IDF 를 인자로 넘깁니다. 없는 색인어가 있으면 ok 가 false 입니다.
+func (s *sqlStore) scoreQuery(ctx context.Context, q search.Query) (query string, args []any, ok bool, err error) {
+	terms := q.Terms()
+	termArgs := make([]any, len(terms))
+	for i, t := range terms {
+		termArgs[i] = t
+	}
+
+	var docs int
+	var avg float64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*), COALESCE(AVG(length), 0) FROM search_docs").Scan(&docs, &avg); err != nil {
+		return "", nil, false, fmt.Errorf("검색 색인 통계 조회 실패: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT term, COUNT(*) FROM search_terms WHERE term IN ("+placeholders(len(terms))+") GROUP BY term", termArgs...,
+	)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("검색 색인 통계 조회 실패: %w", err)
+	}
+	defer rows.Close()
+	df := make(map[string]int, len(terms))
+	for rows.Next() {
+		var term string
+		var n int
+		if err := rows.Scan(&term, &n); err != nil {
+			return "", nil, false, fmt.Errorf("검색 색인 통계 조회 실패: %w", err)
+		}
+		df[term] = n
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, false, fmt.Errorf("검색 색인 통계 조회 실패: %w", err)
+	}
+	if len(df) < len(terms) || avg == 0 {
+		return "", nil, false, nil
+	}
+
+	// BM25: idf × tf·(k1+1) / (tf + k1·(1−b) + k1·b/avgdl·length)
+	var idf strings.Builder
+	idf.WriteString("CASE t.term")
+	for _, t := range terms {
+		idf.WriteString(" WHEN ? THEN ?")
+		args = append(args, t, search.IDF(docs, df[t]))
+	}
+	idf.WriteString(" END")
+	args = append(args, search.K1+1, search.K1*(1-search.B), search.K1*search.B/avg)
+	args = append(args, termArgs...)
+	args = append(args, len(terms))
+
+	query = "SELECT t.article_id, SUM(" + idf.String() + " * t.tf * ? / (t.tf + ? + ? * d.length)) AS score " +
+		"FROM search_terms t JOIN search_docs d ON d.article_id = t.article_id " +
+		"WHERE t.term IN (" + placeholders(len(terms)) + ") GROUP BY t.article_id HAVING COUNT(*) = ?"
+	return query, args, true, nil
+}
+
+// filterClauses 는 출처와 발행 시각 범위 조건을 만듭니다.
+func filterClauses(source string, since, until time.Time) (where []string, args []any) {
+	if source != "" {
+		where = append(where, "source = ?")
+		args = append(args, source)
+	}
+	if !since.IsZero() {
+		where = append(where, "pubDate >= ?")
+		args = append(args, since.UTC())
+	}
+	if !until.IsZero() {
+		where = append(where, "pubDate < ?")
+		args = append(args, until.UTC())
+	}
+	return where, args
+}
+
+// likeClause 는 제목이나 평문 설명에 keyword 가 들어 있다는 조건을 더합니다.
+func likeClause(where []string, args []any, keyword string) ([]string, []any) {
+	pattern := "%" + escapeLike(keyword) + "%"
+	return append(where, "(title LIKE ? ESCAPE '!' OR description_text LIKE ? ESCAPE '!')"),
+		append(args, pattern, pattern)
+}
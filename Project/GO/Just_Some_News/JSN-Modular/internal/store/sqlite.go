@@ -0,0 +1,34 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"jsn-modular/internal/db"
+)
+
+// sqliteDialect 는 SQLite 의 ON CONFLICT ... DO UPDATE 문법입니다.
+// 충돌 대상을 생략해 link, canonical_link 어느 UNIQUE 제약에 걸려도 갱신합니다.
+var sqliteDialect = dialect{
+	upsertClause: "ON CONFLICT DO UPDATE SET",
+	excluded:     func(col string) string { return "excluded." + col },
+	// 신규와 갱신 모두 1
+	updateAffected: 1,
+	// SQLite 정수는 부호 있는 64비트라 비트 그대로 저장
+	hashArg:      func(fp uint64) any { return int64(fp) },
+	insertIgnore: "INSERT OR IGNORE",
+}
+
+// openSQLite 는 sqlite:///abs/path.db 또는 sqlite://rel/path.db 형식의 DSN 으로 엽니다.
+func openSQLite(u *url.URL) (*sql.DB, error) {
+	path := u.Opaque
+	if path == "" {
+		path = u.Host + u.Path
+	}
+	if path == "" {
+		return nil, fmt.Errorf("SQLite 파일 경로 없음: %q", u.String())
+	}
+
+	return db.OpenSQLite(path)
+}
@@ -0,0 +1,665 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/internal/simhash"
+)
+
+// upsertBatchSize 는 다중 행 INSERT 한 번에 넣는 최대 행 수입니다.
+const upsertBatchSize = 100
+
+// articleColumns 는 upsert 대상 컬럼 순서입니다 (upsertArgs 와 일치해야 함).
+const articleColumns = "source, title, link, canonical_link, pubDate, date_guessed, description, description_text, simhash"
+
+// articleSelect 는 Article 로 읽어 들이는 컬럼 순서입니다 (scanArticle 과 일치해야 함).
+const articleSelect = "id, source, title, link, COALESCE(canonical_link, ''), pubDate, date_guessed, COALESCE(description, ''), " +
+	"COALESCE(description_text, ''), COALESCE(content, ''), word_count, content_status, simhash, cluster_id, collected_at"
+
+// dialect 는 MariaDB 와 SQLite 의 SQL 차이입니다.
+type dialect struct {
+	// lockClause 는 upsert 전 기존 행을 잠그는 절입니다. SQLite 는 쓰기 트랜잭션 자체가 DB 를 잠급니다.
+	lockClause string
+	// upsertClause 는 충돌 시 갱신 절의 시작입니다.
+	upsertClause string
+	// excluded 는 충돌한 삽입 값을 가리키는 식을 만듭니다.
+	excluded func(col string) string
+	// updateAffected 는 upsert 로 갱신된 행 하나가 RowsAffected 에 더하는 값입니다.
+	updateAffected int64
+	// hashArg 는 simhash 를 드라이버가 받는 값으로 바꿉니다.
+	hashArg func(uint64) any
+	// insertIgnore 는 키가 충돌하면 아무것도 하지 않는 INSERT 입니다.
+	insertIgnore string
+}
+
+// sqlStore 는 database/sql 위의 공통 Store 구현입니다.
+type sqlStore struct {
+	db   *sql.DB
+	d    dialect
+	opts Options
+}
+
+func (s *sqlStore) Close() error { return s.db.Close() }
+
+func (s *sqlStore) Exists(ctx context.Context, canonical string) (bool, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM security_articles WHERE canonical_link = ?", canonical).Scan(&n)
+	return n > 0, err
+}
+
+func (s *sqlStore) Insert(ctx context.Context, a Article) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		"INSERT INTO security_articles ("+articleColumns+") VALUES ("+placeholders(9)+")",
+		s.upsertArgs(a)...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("기사 삽입 실패: %w", err)
+	}
+	if a.ID, err = res.LastInsertId(); err != nil {
+		return 0, err
+	}
+	return a.ID, s.indexArticles(ctx, s.db, []Article{a})
+}
+
+// existingRow 는 upsert 전에 잠가 둔 기존 행의 비교용 값입니다.
+type existingRow struct {
+	Title       string
+	Description string
+	PubDate     time.Time
+	Clustered   bool // cluster_id 가 있는지
+	Pending     bool // canonical_link 가 NULL (백필 충돌, jsn dedupe 대기)
+}
+
+// UpsertBatch 는 기존 행을 잠가 신규/갱신/변경 없음을 정확히 나눈 뒤, 변경분만 준비된
+// 다중 행 upsert 로 쓰고 신규 기사는 유사 기사 묶음에 배정합니다.
+// 갱신된 기사는 이미 속한 묶음을 바꾸지 않고 (알림/다이제스트에 나간 묶음이 흔들리지 않도록),
+// 지문이 없어 묶음이 없던 기사만 새 지문으로 배정합니다. 도중에 실패하면 전체를 롤백합니다.
+func (s *sqlStore) UpsertBatch(ctx context.Context, articles []Article) (res UpsertResult, err error) {
+	if len(articles) == 0 {
+		return res, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return res, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	existing, err := s.lockExisting(ctx, tx, articles)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+
+	var inserts, updates, unclustered []Article
+	for _, a := range articles {
+		old, ok := existing[a.Canonical]
+		if !ok {
+			old, ok = existing[a.Link]
+		}
+		switch {
+		case existing[a.Link].Pending:
+			// 정규화 URL 을 다른 기사가 갖고 있어 쓰면 UNIQUE 충돌. jsn dedupe 로 합칠 때까지 그대로 둠
+			res.Unchanged++
+		case !ok:
+			inserts = append(inserts, a)
+		case old.Title != a.Title || old.Description != a.Description ||
+			(!a.DateGuessed && !old.PubDate.Equal(a.PubDate)):
+			updates = append(updates, a)
+			if !old.Clustered {
+				unclustered = append(unclustered, a)
+			}
+		default:
+			res.Unchanged++
+		}
+	}
+
+	changed := append(append([]Article(nil), inserts...), updates...)
+	affected, err := s.execUpsert(ctx, tx, changed)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	// 잠근 행과 다른 결과면 (잠금 밖의 동시 쓰기 등) 집계를 믿을 수 없으므로 전체를 되돌림
+	if want := int64(len(inserts)) + s.d.updateAffected*int64(len(updates)); affected != want {
+		return UpsertResult{}, fmt.Errorf("upsert 영향 행 수 불일치: %d (예상 %d)", affected, want)
+	}
+
+	// 갱신한 행도 제목/설명이 바뀌었으므로 함께 다시 색인
+	ids, err := lookupIDs(ctx, tx, changed)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	for i := range changed {
+		changed[i].ID = ids[changed[i].Canonical]
+	}
+	if err := s.indexArticles(ctx, tx, changed); err != nil {
+		return UpsertResult{}, err
+	}
+	clusters, err := s.loadClusters(ctx, tx)
+	if err != nil {
+		return UpsertResult{}, fmt.Errorf("유사 기사 묶음 조회 실패: %w", err)
+	}
+	for _, a := range inserts {
+		id := ids[a.Canonical]
+		clusterID, joined, err := clusters.assign(ctx, tx, s.d, id, a.SimHash)
+		if err != nil {
+			return UpsertResult{}, fmt.Errorf("유사 기사 묶음 배정 실패: %w", err)
+		}
+		res.New = append(res.New, NewArticle{ID: id, Title: a.Title, Link: a.Link, ClusterID: clusterID, Joined: joined})
+	}
+	for _, a := range unclustered {
+		if _, _, err := clusters.assign(ctx, tx, s.d, ids[a.Canonical], a.SimHash); err != nil {
+			return UpsertResult{}, fmt.Errorf("유사 기사 묶음 배정 실패: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return UpsertResult{}, fmt.Errorf("커밋 실패: %w", err)
+	}
+	res.Inserted, res.Updated = len(inserts), len(updates)
+	return res, nil
+}
+
+// lockExisting 은 정규화 URL 또는 원본 링크가 일치하는 기존 행을 잠그고
+// 두 키 모두로 찾을 수 있는 맵을 반환합니다.
+func (s *sqlStore) lockExisting(ctx context.Context, tx *sql.Tx, articles []Article) (map[string]existingRow, error) {
+	existing := make(map[string]existingRow)
+	for start := 0; start < len(articles); start += upsertBatchSize {
+		chunk := articles[start:min(start+upsertBatchSize, len(articles))]
+		marks := placeholders(len(chunk))
+		args := make([]any, 0, 2*len(chunk))
+		for _, a := range chunk {
+			args = append(args, a.Canonical)
+		}
+		for _, a := range chunk {
+			args = append(args, a.Link)
+		}
+
+		q := "SELECT canonical_link, link, title, COALESCE(description, ''), pubDate, cluster_id IS NOT NULL FROM security_articles " +
+			"WHERE canonical_link IN (" + marks + ") OR link IN (" + marks + ")" + s.d.lockClause
+		rows, err := tx.QueryContext(ctx, q, args...)
+		if err != nil {
+			return nil, fmt.Errorf("기존 기사 조회 실패: %w", err)
+		}
+		for rows.Next() {
+			var canonical sql.NullString
+			var link string
+			var old existingRow
+			if err := rows.Scan(&canonical, &link, &old.Title, &old.Description, &old.PubDate, &old.Clustered); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("기존 기사 조회 실패: %w", err)
+			}
+			old.Pending = !canonical.Valid
+			if canonical.Valid {
+				existing[canonical.String] = old
+			}
+			existing[link] = old
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("기존 기사 조회 실패: %w", err)
+		}
+	}
+	return existing, nil
+}
+
+// execUpsert 는 준비된 다중 행 upsert 문으로 행을 나눠 쓰고 전체 영향 행 수를 반환합니다.
+// 같은 크기의 묶음은 준비된 문을 재사용합니다.
+func (s *sqlStore) execUpsert(ctx context.Context, tx *sql.Tx, articles []Article) (int64, error) {
+	stmts := make(map[int]*sql.Stmt)
+	defer func() {
+		for _, st := range stmts {
+			st.Close()
+		}
+	}()
+
+	var affected int64
+	for start := 0; start < len(articles); start += upsertBatchSize {
+		chunk := articles[start:min(start+upsertBatchSize, len(articles))]
+		st, ok := stmts[len(chunk)]
+		if !ok {
+			var err error
+			if st, err = tx.PrepareContext(ctx, s.upsertQuery(len(chunk))); err != nil {
+				return 0, fmt.Errorf("upsert 준비 실패: %w", err)
+			}
+			stmts[len(chunk)] = st
+		}
+
+		args := make([]any, 0, len(chunk)*9)
+		for _, a := range chunk {
+			args = append(args, s.upsertArgs(a)...)
+		}
+		res, err := st.ExecContext(ctx, args...)
+		if err != nil {
+			return 0, fmt.Errorf("upsert 실패: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		affected += n
+	}
+	return affected, nil
+}
+
+func (s *sqlStore) upsertArgs(a Article) []any {
+	return []any{a.Source, a.Title, a.Link, a.Canonical, a.PubDate.UTC(), a.DateGuessed, a.Description, a.DescriptionText, s.d.hashArg(a.SimHash)}
+}
+
+// upsertQuery 는 n 행짜리 다중 행 upsert 문을 만듭니다.
+// 기존 행의 source 는 유지하고, 날짜를 추정한 경우에는 기존 pubDate 를 덮어쓰지 않습니다.
+func (s *sqlStore) upsertQuery(n int) string {
+	row := "(" + placeholders(9) + ")"
+	values := strings.TrimSuffix(strings.Repeat(row+", ", n), ", ")
+	ex := s.d.excluded
+	return "INSERT INTO security_articles (" + articleColumns + ") VALUES " + values + `
+	` + s.d.upsertClause + `
+		title = ` + ex("title") + `,
+		link = ` + ex("link") + `,
+		canonical_link = ` + ex("canonical_link") + `,
+		pubDate = CASE WHEN ` + ex("date_guessed") + ` THEN pubDate ELSE ` + ex("pubDate") + ` END,
+		date_guessed = CASE WHEN ` + ex("date_guessed") + ` THEN date_guessed ELSE FALSE END,
+		description = ` + ex("description") + `,
+		description_text = ` + ex("description_text") + `,
+		simhash = ` + ex("simhash")
+}
+
+// lookupIDs 는 신규 삽입한 행의 id 를 정규화 URL 로 조회합니다.
+// 다중 행 upsert 는 auto-increment 가 연속이라는 보장이 없어 LastInsertId 를 쓰지 않습니다.
+func lookupIDs(ctx context.Context, tx *sql.Tx, articles []Article) (map[string]int64, error) {
+	ids := make(map[string]int64, len(articles))
+	for start := 0; start < len(articles); start += upsertBatchSize {
+		chunk := articles[start:min(start+upsertBatchSize, len(articles))]
+		args := make([]any, 0, len(chunk))
+		for _, a := range chunk {
+			args = append(args, a.Canonical)
+		}
+		rows, err := tx.QueryContext(ctx,
+			"SELECT id, canonical_link FROM security_articles WHERE canonical_link IN ("+placeholders(len(chunk))+")",
+			args...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("신규 기사 id 조회 실패: %w", err)
+		}
+		for rows.Next() {
+			var id int64
+			var canonical string
+			if err := rows.Scan(&id, &canonical); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			ids[canonical] = id
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("신규 기사 id 조회 실패: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+// clusterIndex 는 최근 갱신된 story_clusters 의 지문입니다.
+// UpsertBatch 트랜잭션 안에서 불러오고 새로 만든 묶음도 이어서 추가합니다.
+type clusterIndex struct {
+	threshold int
+	clusters  []clusterEntry
+}
+
+type clusterEntry struct {
+	ID      int64
+	SimHash uint64
+}
+
+// loadClusters 는 ClusterWindow 안에 갱신된 묶음을 불러옵니다.
+func (s *sqlStore) loadClusters(ctx context.Context, tx *sql.Tx) (*clusterIndex, error) {
+	idx := &clusterIndex{threshold: s.opts.ClusterThreshold}
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id, simhash FROM story_clusters WHERE last_seen >= ?",
+		time.Now().UTC().Add(-s.opts.ClusterWindow),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c clusterEntry
+		if err := rows.Scan(&c.ID, hashScanner{&c.SimHash}); err != nil {
+			return nil, err
+		}
+		idx.clusters = append(idx.clusters, c)
+	}
+	return idx, rows.Err()
+}
+
+// nearest 는 해밍 거리가 임계치 이내인 가장 가까운 묶음을 찾습니다. 없으면 0 입니다.
+func (idx *clusterIndex) nearest(fp uint64) int64 {
+	best, bestDist := int64(0), idx.threshold+1
+	for _, c := range idx.clusters {
+		if d := simhash.Distance(c.SimHash, fp); d < bestDist {
+			best, bestDist = c.ID, d
+		}
+	}
+	return best
+}
+
+// assign 은 기사를 가장 가까운 묶음에 넣고, 임계치 안에 없으면 새 묶음을 만듭니다.
+// 반환값은 묶음 ID 와 기존 묶음에 합류했는지 여부입니다.
+// 지문이 0 (본문이 비었거나 문장 부호뿐) 이면 서로 무관한 기사가 한 묶음이 되므로 배정하지 않습니다.
+func (idx *clusterIndex) assign(ctx context.Context, tx *sql.Tx, d dialect, articleID int64, fp uint64) (int64, bool, error) {
+	if fp == 0 {
+		return 0, false, nil
+	}
+	now := time.Now().UTC()
+
+	best := idx.nearest(fp)
+	joined := best != 0
+	if joined {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE story_clusters SET article_count = article_count + 1, last_seen = ? WHERE id = ?",
+			now, best,
+		); err != nil {
+			return 0, false, err
+		}
+	} else {
+		res, err := tx.ExecContext(ctx,
+			"INSERT INTO story_clusters (simhash, representative_id, article_count, first_seen, last_seen) VALUES (?, ?, 1, ?, ?)",
+			d.hashArg(fp), articleID, now, now,
+		)
+		if err != nil {
+			return 0, false, err
+		}
+		if best, err = res.LastInsertId(); err != nil {
+			return 0, false, err
+		}
+		idx.clusters = append(idx.clusters, clusterEntry{ID: best, SimHash: fp})
+	}
+
+	_, err := tx.ExecContext(ctx, "UPDATE security_articles SET cluster_id = ? WHERE id = ?", best, articleID)
+	return best, joined, err
+}
+
+func (s *sqlStore) UpdateContent(ctx context.Context, id int64, c Content) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE security_articles SET content = ?, word_count = ?, content_status = ? WHERE id = ?",
+		c.Text, c.WordCount, c.Status, id,
+	)
+	return err
+}
+
+func (s *sqlStore) Get(ctx context.Context, id int64) (Article, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+articleSelect+" FROM security_articles WHERE id = ?", id)
+	if err != nil {
+		return Article{}, fmt.Errorf("기사 조회 실패: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return Article{}, fmt.Errorf("기사 조회 실패: %w", err)
+		}
+		return Article{}, ErrNotFound
+	}
+	a, err := scanArticle(rows)
+	if err != nil {
+		return Article{}, fmt.Errorf("기사 조회 실패: %w", err)
+	}
+	return a, nil
+}
+
+func (s *sqlStore) Query(ctx context.Context, q Query) ([]Article, error) {
+	where, args := filterClauses(q.Source, q.Since, q.Until)
+	if q.Keyword != "" {
+		where, args = likeClause(where, args, q.Keyword)
+	}
+	if !q.CollectedSince.IsZero() {
+		where = append(where, "collected_at >= ?")
+		args = append(args, q.CollectedSince.UTC())
+	}
+	if !q.CollectedUntil.IsZero() {
+		where = append(where, "collected_at < ?")
+		args = append(args, q.CollectedUntil.UTC())
+	}
+	if q.Before != nil {
+		where = append(where, "(pubDate < ? OR (pubDate = ? AND id < ?))")
+		args = append(args, q.Before.PubDate.UTC(), q.Before.PubDate.UTC(), q.Before.ID)
+	}
+
+	query := "SELECT " + articleSelect + " FROM security_articles"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY pubDate DESC, id DESC"
+	if q.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(q.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("기사 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Article
+	for rows.Next() {
+		a, err := scanArticle(rows)
+		if err != nil {
+			return nil, fmt.Errorf("기사 조회 실패: %w", err)
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// scanArticle 은 articleSelect 컬럼을 읽습니다. extra 는 그 뒤에 붙은 컬럼입니다.
+func scanArticle(rows *sql.Rows, extra ...any) (Article, error) {
+	var a Article
+	var clusterID sql.NullInt64
+	var collected sql.NullTime
+	dest := []any{&a.ID, &a.Source, &a.Title, &a.Link, &a.Canonical, &a.PubDate, &a.DateGuessed,
+		&a.Description, &a.DescriptionText, &a.Content, &a.WordCount, &a.ContentStatus,
+		hashScanner{&a.SimHash}, &clusterID, &collected}
+	err := rows.Scan(append(dest, extra...)...)
+	a.ClusterID, a.CollectedAt = clusterID.Int64, collected.Time
+	return a, err
+}
+
+func (s *sqlStore) Stats(ctx context.Context) ([]SourceStats, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT source, COUNT(*), MIN(pubDate), MAX(pubDate), MAX(collected_at) FROM security_articles GROUP BY source ORDER BY source",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("통계 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var out []SourceStats
+	for rows.Next() {
+		var st SourceStats
+		if err := rows.Scan(&st.Source, &st.Articles, timeScanner{&st.Oldest}, timeScanner{&st.Newest}, timeScanner{&st.LastCollected}); err != nil {
+			return nil, fmt.Errorf("통계 조회 실패: %w", err)
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqlStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	for _, table := range []string{"search_terms", "search_docs"} {
+		if _, err := s.db.ExecContext(ctx,
+			"DELETE FROM "+table+" WHERE article_id IN (SELECT id FROM security_articles WHERE pubDate < ?)",
+			before.UTC(),
+		); err != nil {
+			return 0, fmt.Errorf("검색 색인 삭제 실패: %w", err)
+		}
+	}
+	if _, err := s.db.ExecContext(ctx,
+		"DELETE FROM alert_deliveries WHERE article_id IN (SELECT id FROM security_articles WHERE pubDate < ?)",
+		before.UTC(),
+	); err != nil {
+		return 0, fmt.Errorf("알림 기록 삭제 실패: %w", err)
+	}
+	res, err := s.db.ExecContext(ctx, "DELETE FROM security_articles WHERE pubDate < ?", before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("기사 삭제 실패: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	// 남은 기사가 없고 더 이상 갱신되지 않는 묶음도 정리
+	if _, err := s.db.ExecContext(ctx,
+		"DELETE FROM story_clusters WHERE last_seen < ? AND id NOT IN "+
+			"(SELECT cluster_id FROM security_articles WHERE cluster_id IS NOT NULL)",
+		before.UTC(),
+	); err != nil {
+		return n, fmt.Errorf("유사 기사 묶음 삭제 실패: %w", err)
+	}
+	return n, nil
+}
+
+func (s *sqlStore) LastDigest(ctx context.Context, kind string) (time.Time, error) {
+	var end time.Time
+	err := s.db.QueryRowContext(ctx, "SELECT window_end FROM digest_state WHERE kind = ?", kind).Scan(&end)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("다이제스트 기록 조회 실패: %w", err)
+	}
+	return end, nil
+}
+
+func (s *sqlStore) SaveDigest(ctx context.Context, kind string, start, end time.Time, articles int) error {
+	ex := s.d.excluded
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO digest_state (kind, window_start, window_end, articles, sent_at) VALUES (?, ?, ?, ?, ?)
+		`+s.d.upsertClause+` window_start = `+ex("window_start")+`, window_end = `+ex("window_end")+`,
+		articles = `+ex("articles")+`, sent_at = `+ex("sent_at"),
+		kind, start.UTC(), end.UTC(), articles, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("다이제스트 기록 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *sqlStore) LoadFeedState(ctx context.Context, feed, url string) (FeedState, error) {
+	var st FeedState
+	var storedURL string
+	var lastFailure sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		"SELECT url, etag, last_modified, consecutive_failures, last_failure_at FROM feed_state WHERE feed = ?", feed,
+	).Scan(&storedURL, &st.ETag, &st.LastModified, &st.Failures, &lastFailure)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && storedURL != url) {
+		return FeedState{}, nil
+	}
+	st.LastFailure = lastFailure.Time
+	return st, err
+}
+
+func (s *sqlStore) SaveFeedState(ctx context.Context, feed, url, etag, lastModified string) error {
+	ex := s.d.excluded
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO feed_state (feed, url, etag, last_modified, consecutive_failures) VALUES (?, ?, ?, ?, 0)
+		`+s.d.upsertClause+` url = `+ex("url")+`, etag = `+ex("etag")+`, last_modified = `+ex("last_modified")+`,
+		consecutive_failures = 0`,
+		feed, url, etag, lastModified,
+	)
+	return err
+}
+
+func (s *sqlStore) MarkFeedAlive(ctx context.Context, feed string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE feed_state SET consecutive_failures = 0 WHERE feed = ?", feed)
+	return err
+}
+
+func (s *sqlStore) RecordFeedFailure(ctx context.Context, feed, url string, cause error) error {
+	ex := s.d.excluded
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO feed_state (feed, url, consecutive_failures, last_failure_at, last_error) VALUES (?, ?, 1, ?, ?)
+		`+s.d.upsertClause+` consecutive_failures = consecutive_failures + 1,
+		last_failure_at = `+ex("last_failure_at")+`, last_error = `+ex("last_error"),
+		feed, url, time.Now().UTC(), errorMessage(cause),
+	)
+	return err
+}
+
+// hashScanner 는 드라이버에 따라 부호 있는/없는 정수 또는 문자열로 오는 simhash 를 읽습니다.
+type hashScanner struct{ v *uint64 }
+
+func (h hashScanner) Scan(src any) error {
+	switch x := src.(type) {
+	case int64:
+		*h.v = uint64(x)
+	case uint64:
+		*h.v = x
+	case []byte:
+		return h.parse(string(x))
+	case string:
+		return h.parse(x)
+	case nil:
+		*h.v = 0
+	default:
+		return fmt.Errorf("simhash 형식 오류: %T", src)
+	}
+	return nil
+}
+
+func (h hashScanner) parse(s string) error {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*h.v = n
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	*h.v = uint64(n)
+	return err
+}
+
+// timeScanner 는 집계 함수 결과처럼 컬럼 타입 정보 없이 문자열로 오는 시각도 읽습니다 (SQLite).
+type timeScanner struct{ v *time.Time }
+
+func (t timeScanner) Scan(src any) error {
+	var s string
+	switch x := src.(type) {
+	case time.Time:
+		*t.v = x
+		return nil
+	case nil:
+		*t.v = time.Time{}
+		return nil
+	case []byte:
+		s = string(x)
+	case string:
+		s = x
+	default:
+		return fmt.Errorf("시각 형식 오류: %T", src)
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", time.RFC3339Nano, time.DateTime} {
+		if v, err := time.Parse(layout, s); err == nil {
+			*t.v = v
+			return nil
+		}
+	}
+	return fmt.Errorf("시각 형식 오류: %q", s)
+}
+
+// escapeLike 는 LIKE 패턴의 와일드카드를 ESCAPE '!' 기준으로 이스케이프합니다.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+// placeholders 는 "?, ?, ..." 를 n 개 만듭니다.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,371 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jsn-modular/internal/simhash"
+)
+
+var testOptions = Options{ClusterThreshold: 3, ClusterWindow: 48 * time.Hour}
+
+// backends 는 같은 계약 테스트를 돌릴 저장소들입니다. SQLite 는 임시 파일에 마이그레이션까지 적용합니다.
+func backends(t *testing.T) map[string]func(t *testing.T) Store {
+	return map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store { return NewMemory(testOptions) },
+		"sqlite": func(t *testing.T) Store {
+			s, err := Open("sqlite://"+filepath.Join(t.TempDir(), "jsn.db"), testOptions)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			t.Cleanup(func() { s.Close() })
+			return s
+		},
+	}
+}
+
+// eachBackend 는 fn 을 모든 백엔드의 새 저장소로 실행합니다.
+func eachBackend(t *testing.T, fn func(t *testing.T, s Store)) {
+	for name, open := range backends(t) {
+		t.Run(name, func(t *testing.T) { fn(t, open(t)) })
+	}
+}
+
+var basePub = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testArticle(n int, pub time.Time) Article {
+	link := fmt.Sprintf("https://example.com/news/%d", n)
+	return Article{
+		Source:          "test",
+		Title:           fmt.Sprintf("기사 %d", n),
+		Link:            link,
+		Canonical:       link,
+		PubDate:         pub,
+		Description:     "<p>설명</p>",
+		DescriptionText: "설명",
+	}
+}
+
+func TestUpsertBatchCounts(t *testing.T) {
+	eachBackend(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		batch := []Article{testArticle(1, basePub), testArticle(2, basePub), testArticle(3, basePub)}
+
+		res, err := s.UpsertBatch(ctx, batch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Inserted != 3 || res.Updated != 0 || res.Unchanged != 0 || len(res.New) != 3 {
+			t.Fatalf("첫 upsert = %+v, want 3/0/0", res)
+		}
+
+		res, err = s.UpsertBatch(ctx, batch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Inserted != 0 || res.Updated != 0 || res.Unchanged != 3 || len(res.New) != 0 {
+			t.Fatalf("같은 묶음 재실행 = %+v, want 0/0/3", res)
+		}
+
+		// 제목 변경 1건, 날짜만 추정된 1건 (기존 pubDate 유지 → 변경 없음), 신규 1건
+		changed := testArticle(1, basePub)
+		changed.Title = "기사 1 (수정)"
+		guessed := testArticle(2, basePub.Add(time.Hour))
+		guessed.DateGuessed = true
+		res, err = s.UpsertBatch(ctx, []Article{changed, guessed, testArticle(3, basePub), testArticle(4, basePub)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Inserted != 1 || res.Updated != 1 || res.Unchanged != 2 {
+			t.Fatalf("혼합 묶음 = %+v, want 1/1/2", res)
+		}
+
+		all, err := s.Query(ctx, Query{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 4 {
+			t.Fatalf("기사 %d건, want 4", len(all))
+		}
+		for _, a := range all {
+			if a.Link == changed.Link && a.Title != changed.Title {
+				t.Errorf("갱신된 제목 = %q, want %q", a.Title, changed.Title)
+			}
+			if a.Link == guessed.Link && !a.PubDate.Equal(basePub) {
+				t.Errorf("추정 날짜가 pubDate 를 덮어씀: %v", a.PubDate)
+			}
+		}
+	})
+}
+
+func TestUpsertBatchCanonicalDedup(t *testing.T) {
+	eachBackend(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		if _, err := s.UpsertBatch(ctx, []Article{testArticle(1, basePub)}); err != nil {
+			t.Fatal(err)
+		}
+
+		// 추적 파라미터가 붙은 다른 링크지만 정규화 URL 은 같은 기사
+		dup := testArticle(1, basePub)
+		dup.Link = "http://example.com/news/1?utm_source=rss"
+		res, err := s.UpsertBatch(ctx, []Article{dup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Inserted != 0 || res.Unchanged != 1 {
+			t.Fatalf("정규화 URL 중복 = %+v, want 변경 없음 1건", res)
+		}
+
+		dup.Title = "기사 1 (수정)"
+		res, err = s.UpsertBatch(ctx, []Article{dup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Inserted != 0 || res.Updated != 1 {
+			t.Fatalf("정규화 URL 중복 갱신 = %+v, want 갱신 1건", res)
+		}
+
+		all, err := s.Query(ctx, Query{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 1 || all[0].Title != dup.Title || all[0].Link != dup.Link {
+			t.Fatalf("기사 = %+v, want 갱신된 1건", all)
+		}
+		if ok, err := s.Exists(ctx, dup.Canonical); err != nil || !ok {
+			t.Errorf("Exists(%q) = %v, %v", dup.Canonical, ok, err)
+		}
+	})
+}
+
+func TestUpsertBatchClusters(t *testing.T) {
+	eachBackend(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		text := "국내 VPN 장비에서 인증 없이 원격 코드를 실행할 수 있는 취약점이 발견돼 긴급 보안 업데이트가 배포됐다"
+
+		first, second, empty := testArticle(1, basePub), testArticle(2, basePub), testArticle(3, basePub)
+		first.SimHash = simhash.Fingerprint(text)
+		second.SimHash = simhash.Fingerprint(text + "!")
+		empty.Title, empty.SimHash = "...", simhash.Fingerprint("...")
+		if empty.SimHash != 0 {
+			t.Fatalf("문장 부호뿐인 지문 = %x, want 0", empty.SimHash)
+		}
+
+		res, err := s.UpsertBatch(ctx, []Article{first, second, empty})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.New) != 3 {
+			t.Fatalf("신규 %d건, want 3", len(res.New))
+		}
+		if res.New[0].ClusterID == 0 || res.New[0].Joined {
+			t.Errorf("첫 기사 = %+v, want 새 묶음", res.New[0])
+		}
+		if res.New[1].ClusterID != res.New[0].ClusterID || !res.New[1].Joined {
+			t.Errorf("유사 기사 = %+v, want 묶음 %d 합류", res.New[1], res.New[0].ClusterID)
+		}
+		if res.New[2].ClusterID != 0 || res.New[2].Joined {
+			t.Errorf("지문 없는 기사 = %+v, want 묶음 없음", res.New[2])
+		}
+
+		// 본문이 생겨 지문이 바뀌면 그때 묶음에 배정
+		empty.Title, empty.SimHash = "전혀 다른 랜섬웨어 유포 소식", simhash.Fingerprint("전혀 다른 랜섬웨어 유포 소식")
+		if _, err := s.UpsertBatch(ctx, []Article{empty}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(ctx, res.New[2].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ClusterID == 0 || got.ClusterID == res.New[0].ClusterID {
+			t.Errorf("갱신 후 묶음 = %d, want 새 묶음", got.ClusterID)
+		}
+	})
+}
+
+func TestQueryCursorEqualPubDate(t *testing.T) {
+	eachBackend(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		var batch []Article
+		for i := 1; i <= 5; i++ {
+			batch = append(batch, testArticle(i, basePub))
+		}
+		batch = append(batch, testArticle(6, basePub.Add(-time.Hour)))
+		if _, err := s.UpsertBatch(ctx, batch); err != nil {
+			t.Fatal(err)
+		}
+
+		var pages [][]int64
+		q := Query{Limit: 2}
+		for {
+			page, err := s.Query(ctx, q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			var ids []int64
+			for _, a := range page {
+				ids = append(ids, a.ID)
+			}
+			pages = append(pages, ids)
+			last := page[len(page)-1]
+			q.Before = &Cursor{PubDate: last.PubDate, ID: last.ID}
+		}
+
+		want := [][]int64{{5, 4}, {3, 2}, {1, 6}}
+		if fmt.Sprint(pages) != fmt.Sprint(want) {
+			t.Errorf("페이지 = %v, want %v", pages, want)
+		}
+	})
+}
+
+func TestPrune(t *testing.T) {
+	eachBackend(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		old, recent := testArticle(1, basePub.AddDate(0, 0, -40)), testArticle(2, basePub)
+		res, err := s.UpsertBatch(ctx, []Article{old, recent})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := s.Prune(ctx, basePub.AddDate(0, 0, -30))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Errorf("Prune 삭제 %d건, want 1", n)
+		}
+		if _, err := s.Get(ctx, res.New[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("삭제된 기사 Get 오류 = %v, want ErrNotFound", err)
+		}
+		if _, err := s.Get(ctx, res.New[1].ID); err != nil {
+			t.Errorf("남은 기사 Get 오류: %v", err)
+		}
+		if ok, _ := s.Exists(ctx, old.Canonical); ok {
+			t.Error("삭제된 기사의 정규화 URL 이 남음")
+		}
+	})
+}
+
+func TestFeedStateRoundTrip(t *testing.T) {
+	eachBackend(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		const feed, url = "boannews", "https://www.boannews.com/media/news_rss.xml"
+
+		st, err := s.LoadFeedState(ctx, feed, url)
+		if err != nil || st != (FeedState{}) {
+			t.Fatalf("빈 상태 = %+v, %v", st, err)
+		}
+
+		if err := s.SaveFeedState(ctx, feed, url, `"etag-1"`, "Wed, 14 Oct 2026 09:00:00 GMT"); err != nil {
+			t.Fatal(err)
+		}
+		for range 2 {
+			if err := s.RecordFeedFailure(ctx, feed, url, errors.New("HTTP 503")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		st, err = s.LoadFeedState(ctx, feed, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.ETag != `"etag-1"` || st.LastModified != "Wed, 14 Oct 2026 09:00:00 GMT" || st.Failures != 2 || st.LastFailure.IsZero() {
+			t.Errorf("실패 2회 후 상태 = %+v", st)
+		}
+
+		if err := s.MarkFeedAlive(ctx, feed); err != nil {
+			t.Fatal(err)
+		}
+		st, err = s.LoadFeedState(ctx, feed, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.ETag != `"etag-1"` || st.Failures != 0 {
+			t.Errorf("304 후 상태 = %+v, want 검증자 유지, 실패 0", st)
+		}
+
+		// 피드 URL 이 바뀌면 이전 검증자를 쓰지 않음
+		st, err = s.LoadFeedState(ctx, feed, url+"?v=2")
+		if err != nil || st.ETag != "" || st.LastModified != "" {
+			t.Errorf("다른 URL 상태 = %+v, %v", st, err)
+		}
+	})
+}
+
+func TestOpenReadOnlySchemaBehind(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "jsn.db")
+	readOnly := testOptions
+	readOnly.ReadOnly = true
+
+	if _, err := Open(dsn, readOnly); !errors.Is(err, ErrSchemaBehind) {
+		t.Fatalf("마이그레이션 전 ReadOnly Open 오류 = %v, want ErrSchemaBehind", err)
+	}
+	// 확인만 하고 schema_migrations 도 만들지 않아야 함
+	conn, _, err := OpenDB(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tables int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'").Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if tables != 0 {
+		t.Errorf("ReadOnly Open 이 테이블 %d개를 만듦", tables)
+	}
+
+	s, err := Open(dsn, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	s, err = Open(dsn, readOnly)
+	if err != nil {
+		t.Fatalf("마이그레이션 후 ReadOnly Open: %v", err)
+	}
+	s.Close()
+}
+
+func TestUpsertBatchSkipsPendingCanonical(t *testing.T) {
+	s, err := Open("sqlite://"+filepath.Join(t.TempDir(), "jsn.db"), testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+	if _, err := s.UpsertBatch(ctx, []Article{testArticle(1, basePub)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 백필에서 정규화 URL 이 기사 1 과 겹쳐 NULL 로 남은 기사
+	dup := testArticle(1, basePub)
+	dup.Link = "http://example.com/news/1?utm_source=rss"
+	if _, err := s.(*sqlStore).db.ExecContext(ctx,
+		"INSERT INTO security_articles (title, link, canonical_link, pubDate) VALUES (?, ?, NULL, ?)",
+		dup.Title, dup.Link, basePub,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	dup.Title = "기사 1 (수정)"
+	res, err := s.UpsertBatch(ctx, []Article{dup})
+	if err != nil {
+		t.Fatalf("NULL canonical_link 기사 upsert: %v", err)
+	}
+	if res.Inserted != 0 || res.Updated != 0 || res.Unchanged != 1 {
+		t.Errorf("upsert = %+v, want 변경 없음 1건", res)
+	}
+	all, err := s.Query(ctx, Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("기사 %d건, want 2", len(all))
+	}
+}
@@ -1,26 +1,241 @@
 package main
 
 import (
-	"jsn-modular/internal/db"
-	"jsn-modular/internal/logger"
-	"jsn-modular/internal/rss"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/api"
+	"jsn-modular/internal/store"
+)
+
+// 종료 코드. systemd 의 Restart=, RestartPreventExitStatus= 와 모니터링이 이 값으로 실패를 구분합니다.
+const (
+	exitOK          = 0  // 성공
+	exitError       = 1  // 그 밖의 실행 중 오류 (쿼리, 파일 등)
+	exitUsage       = 2  // 잘못된 명령/플래그
+	exitUnavailable = 3  // 저장소(DB)에 연결할 수 없음
+	exitAllFailed   = 4  // collect: 대상 피드가 모두 실패
+	exitPartial     = 5  // collect: 일부 피드 실패
+	exitConfig      = 78 // 설정 파일/환경 변수를 읽지 못했거나 검증 실패 (sysexits.h 의 EX_CONFIG)
 )
 
+// command 는 jsn 하위 명령 하나입니다. run 은 명령 이름 뒤의 인자를 받아 종료 코드를 반환합니다.
+type command struct {
+	name    string
+	summary string
+	run     func(cfg *config.Config, args []string) int
+}
+
+var commands = []command{
+	{"collect", "활성 피드를 한 번 수집 (명령을 생략하면 collect)", runCollect},
+	{"daemon", "일정에 따라 계속 수집 (schedule.cron, 피드별 interval)", runDaemon},
+	{"init-db", "데이터베이스와 스키마 생성", runInitDB},
+	{"feeds", "피드 목록/추가/삭제 (list, add, remove)", runFeeds},
+	{"search", "키워드로 기사 검색", runSearch},
+	{"export", "기사를 NDJSON/JSON/CSV 로 내보내기", runExport},
+	{"prune", "오래된 기사 삭제", runPrune},
+	{"stats", "출처별 기사 통계", runStats},
+	{"serve", "HTTP API 서버 실행", runServe},
+	{"alerts", "관심 키워드 알림 시험 (test, check)", runAlerts},
+	{"digest", "신규 기사 이메일 다이제스트 발송 (daily, weekly)", runDigest},
+	{"migrate", "스키마 마이그레이션 (status, up, down)", runMigrate},
+	{"dedupe", "정규화 URL 이 겹치는 기존 기사 확인/정리 (--apply)", runDedupe},
+	{"config", "적용된 설정 출력 (print)", runConfig},
+}
+
 func main() {
-	// 1. 로거 설정
-	logFile := logger.Setup()
-	defer logFile.Close()
+	// 0. 설정 플래그 (파일 → JSN_* 환경 변수 → 플래그) 는 명령 이름 앞에 둡니다
+	fs := flag.NewFlagSet("jsn", flag.ExitOnError)
+	fs.Usage = func() { usage(fs) }
+	loader := config.NewLoader(fs)
+	_ = fs.Parse(os.Args[1:])
 
-	// 2. 데이터베이스 초기화
-	database, err := db.InitDB()
+	// 명령 없이 실행하면 수집 (기존 jsn.service 호환)
+	name, args := "collect", fs.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" {
+		if len(args) == 0 {
+			fs.SetOutput(os.Stdout)
+			usage(fs)
+			os.Exit(exitOK)
+		}
+		// jsn help <명령> 은 jsn <명령> -h 와 같음. -h 는 설정을 쓰기 전에 처리되므로 설정을 읽지 않음
+		cmd, ok := findCommand(args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "알 수 없는 명령: %q\n", args[0])
+			os.Exit(exitUsage)
+		}
+		os.Exit(cmd.run(nil, []string{"-h"}))
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %q (jsn help 로 목록 확인)\n", name)
+		os.Exit(exitUsage)
+	}
+	cfg, err := loader.Load()
 	if err != nil {
-		log.Fatalf("인프라 초기화 실패: %v", err)
+		os.Exit(configError(err))
+	}
+	os.Exit(cmd.run(cfg, args))
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "사용법: jsn [설정 플래그] <명령> [명령 플래그] [인자]")
+	fmt.Fprintln(w, "\n명령:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(w, "  %-9s %s\n", "help", "명령 도움말 (jsn help <명령>)")
+	fmt.Fprintln(w, "\n종료 코드: 0 성공, 1 실행 오류, 2 사용법 오류, 3 저장소 연결 실패, 4 모든 피드 실패, 5 일부 피드 실패, 78 설정 오류")
+	fmt.Fprintln(w, "\n설정 플래그:")
+	fs.PrintDefaults()
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// newFlagSet 은 명령별 플래그 집합을 만듭니다. -h 를 주면 usage 와 플래그 설명을 출력합니다.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("jsn "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), usage)
+		n := 0
+		fs.VisitAll(func(*flag.Flag) { n++ })
+		if n > 0 {
+			fmt.Fprintln(fs.Output(), "\n플래그:")
+			fs.PrintDefaults()
+		}
 	}
-	defer database.Close()
+	return fs
+}
+
+// parseArgs 는 명령 플래그를 읽고 위치 인자를 반환합니다. 플래그와 위치 인자는 섞어 써도 됩니다.
+// 진행할 수 없으면 ok 가 false 이고 code 가 종료 코드입니다 (-h 는 0, 잘못된 플래그는 2).
+func parseArgs(fs *flag.FlagSet, args []string) (pos []string, code int, ok bool) {
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, exitOK, false
+			}
+			return nil, exitUsage, false
+		}
+		if fs.NArg() == 0 {
+			return pos, exitOK, true
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
 
-	// 3. 뉴스 수집 실행
-	rss.Collect(database)
+// usageError 는 사용법 오류를 출력하고 종료 코드 2 를 반환합니다.
+func usageError(fs *flag.FlagSet, format string, args ...any) int {
+	fmt.Fprintf(fs.Output(), format+"\n\n", args...)
+	fs.Usage()
+	return exitUsage
+}
+
+// configError 는 설정 오류를 출력하고 종료 코드 78 을 반환합니다.
+func configError(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	return exitConfig
+}
 
-	log.Println(">>> 프로그램 정상 종료.")
+// fail 은 실행 오류를 출력하고 종료 코드를 반환합니다 (저장소 연결 실패는 3, 그 밖에는 1).
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	if errors.Is(err, store.ErrUnavailable) {
+		return exitUnavailable
+	}
+	return exitError
+}
+
+// openStore 는 설정의 DSN 으로 저장소를 엽니다. SQL 백엔드는 스키마 마이그레이션을 적용합니다.
+// 연결하지 못하면 store.ErrUnavailable 을 감싼 오류를 반환합니다.
+func openStore(cfg *config.Config) (store.Store, error) {
+	return store.Open(cfg.DSN(), storeOptions(cfg))
+}
+
+// openReadStore 는 조회 명령 (feeds list, stats, search, export) 용으로 마이그레이션 없이 저장소를 엽니다.
+// 스키마가 뒤처져 있으면 jsn migrate up 을 안내하는 store.ErrSchemaBehind 오류를 반환합니다.
+func openReadStore(cfg *config.Config) (store.Store, error) {
+	opts := storeOptions(cfg)
+	opts.ReadOnly = true
+	return store.Open(cfg.DSN(), opts)
+}
+
+func storeOptions(cfg *config.Config) store.Options {
+	return store.Options{
+		ClusterThreshold: cfg.Cluster.Threshold,
+		ClusterWindow:    cfg.Cluster.Window,
+	}
+}
+
+// timeValue 는 날짜(2006-01-02, 현지 자정), 날짜 시각(2006-01-02 15:04) 또는 RFC 3339 시각 플래그입니다.
+type timeValue struct{ t *time.Time }
+
+func (v timeValue) String() string {
+	if v.t == nil || v.t.IsZero() {
+		return ""
+	}
+	return v.t.Format(time.RFC3339)
+}
+
+func (v timeValue) Set(s string) error {
+	t, err := api.ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*v.t = t
+	return nil
+}
+
+// ageValue 는 time.ParseDuration 형식에 일 단위(예: 90d)를 더한 기간 플래그입니다.
+type ageValue struct{ d *time.Duration }
+
+func (v ageValue) String() string {
+	if v.d == nil || *v.d == 0 {
+		return ""
+	}
+	if *v.d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", *v.d/(24*time.Hour))
+	}
+	return v.d.String()
+}
+
+func (v ageValue) Set(s string) error {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return fmt.Errorf("기간 형식 오류 (예: 90d, 36h): %q", s)
+		}
+		*v.d = time.Duration(n) * 24 * time.Hour
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return fmt.Errorf("기간 형식 오류 (예: 90d, 36h): %q", s)
+	}
+	*v.d = d
+	return nil
 }
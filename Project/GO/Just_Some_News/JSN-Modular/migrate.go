@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/store"
+	"jsn-modular/migrate"
+)
+
+const migrateUsage = `사용법: jsn migrate <status|up|down [N]>
+
+  status    내장 마이그레이션과 적용 여부 출력
+  up        적용하지 않은 마이그레이션을 모두 적용
+  down [N]  최근 적용한 마이그레이션 N개(기본 1) 되돌리기`
+
+// runMigrate 는 jsn migrate 하위 명령을 실행하고 종료 코드를 반환합니다.
+func runMigrate(cfg *config.Config, args []string) int {
+	fs := newFlagSet("migrate", migrateUsage)
+	args, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(args) == 0 {
+		return usageError(fs, "하위 명령이 필요함")
+	}
+	steps := 1
+	switch args[0] {
+	case "status", "up":
+		if len(args) > 1 {
+			return usageError(fs, "알 수 없는 인자: %q", args[1])
+		}
+	case "down":
+		if len(args) > 2 {
+			return usageError(fs, "알 수 없는 인자: %q", args[2])
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return usageError(fs, "잘못된 단계 수: %q", args[1])
+			}
+			steps = n
+		}
+	default:
+		return usageError(fs, "알 수 없는 하위 명령: %q", args[0])
+	}
+
+	conn, dialect, err := store.OpenDB(cfg.DSN())
+	if err != nil {
+		return fail(err)
+	}
+	defer conn.Close()
+	m, err := migrate.New(conn, dialect)
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx := context.Background()
+	var done []migrate.Migration
+	switch args[0] {
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return fail(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, st := range list {
+			status, at := "pending", ""
+			if st.Applied {
+				status, at = "applied", st.AppliedAt.Local().Format(time.DateTime)
+			}
+			if !st.Known {
+				status += " (unknown)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, status, at)
+		}
+		w.Flush()
+		return exitOK
+	case "up":
+		done, err = m.Up(ctx)
+	case "down":
+		done, err = m.Down(ctx, steps)
+	}
+	for _, mig := range done {
+		fmt.Printf("%s: %s\n", args[0], mig)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	if len(done) == 0 {
+		fmt.Println("변경 없음")
+	}
+	return exitOK
+}
@@ -0,0 +1,110 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"jsn-modular/urlnorm"
+)
+
+// dataSteps 는 SQL 만으로 할 수 없어 Go 로 실행하는 데이터 이관입니다. 키는 마이그레이션 이름이며,
+// 같은 이름의 up SQL (주석뿐이어도 됨) 을 실행한 직후 같은 연결, 같은 잠금 안에서 호출합니다.
+var dataSteps = map[string]func(ctx context.Context, conn *sql.Conn) error{
+	"backfill_canonical_link": backfillCanonical,
+}
+
+// backfillBatch 는 canonical_link 를 다시 계산할 때 한 번에 읽는 기사 수입니다.
+const backfillBatch = 500
+
+// backfillCanonical 은 모든 기사의 canonical_link 를 urlnorm.Canonical(link) 로 다시 계산합니다.
+// 정규화 결과를 이미 다른 기사가 갖고 있으면 어느 쪽도 지우지 않고 canonical_link 를 NULL 로 남겨
+// 로그에 남깁니다. 합치는 작업은 jsn dedupe --apply (Dedupe) 가 따로 합니다.
+// 정규화할 수 없는 링크는 그대로 둡니다. 행마다 독립적으로 갱신하므로 중간에 실패해도
+// 다음 up 에서 처음부터 다시 실행하면 되고, 다시 실행해도 결과는 같습니다.
+func backfillCanonical(ctx context.Context, conn *sql.Conn) error {
+	type row struct {
+		id        int64
+		link      string
+		canonical sql.NullString
+	}
+
+	var lastID int64
+	updated, conflicts := 0, 0
+	for {
+		rows, err := conn.QueryContext(ctx,
+			"SELECT id, link, canonical_link FROM security_articles WHERE id > ? ORDER BY id LIMIT ?",
+			lastID, backfillBatch,
+		)
+		if err != nil {
+			return fmt.Errorf("기사 조회 실패: %w", err)
+		}
+		var batch []row
+		for rows.Next() {
+			var r row
+			if err := rows.Scan(&r.id, &r.link, &r.canonical); err != nil {
+				rows.Close()
+				return fmt.Errorf("기사 조회 실패: %w", err)
+			}
+			batch = append(batch, r)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("기사 조회 실패: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastID = batch[len(batch)-1].id
+
+		for _, r := range batch {
+			canonical, err := urlnorm.Canonical(r.link, "")
+			if err != nil || canonical == r.canonical.String {
+				continue
+			}
+
+			holder, err := canonicalHolder(ctx, conn, canonical)
+			if err != nil {
+				return err
+			}
+			var value any = canonical
+			if holder != 0 {
+				if !r.canonical.Valid {
+					continue // 이미 충돌로 남겨 둔 기사
+				}
+				log.Printf(">>> canonical_link 충돌: 기사 %d (%s) 의 정규화 URL %s 을 기사 %d 가 사용 중, NULL 로 남김 (jsn dedupe 로 확인)",
+					r.id, r.link, canonical, holder)
+				value = nil
+				conflicts++
+			}
+			if _, err := conn.ExecContext(ctx,
+				"UPDATE security_articles SET canonical_link = ? WHERE id = ?", value, r.id,
+			); err != nil {
+				return fmt.Errorf("canonical_link 갱신 실패: %w", err)
+			}
+			if holder == 0 {
+				updated++
+			}
+		}
+	}
+
+	if updated > 0 || conflicts > 0 {
+		log.Printf(">>> canonical_link 재계산: 갱신 %d건 / 충돌 %d건", updated, conflicts)
+	}
+	return nil
+}
+
+// canonicalHolder 는 canonical_link 가 canonical 인 기사의 id 를 반환합니다. 없으면 0 입니다.
+func canonicalHolder(ctx context.Context, q querier, canonical string) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx, "SELECT id FROM security_articles WHERE canonical_link = ?", canonical).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("canonical_link 조회 실패: %w", err)
+	}
+	return id, nil
+}
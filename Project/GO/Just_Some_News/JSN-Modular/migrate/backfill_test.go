@@ -0,0 +1,180 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestBackfillCanonicalAndDedupe(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "jsn.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// 0008 처럼 canonical_link 가 원본 링크로 채워진 기존 기사
+	now := time.Now().UTC()
+	articles := []struct {
+		id      int64
+		link    string
+		cluster any
+	}{
+		{1, "http://Example.com/a?utm_source=rss", 1}, // 정규화 후 3 과 겹침 → NULL
+		{2, "https://example.com/b/", nil},            // 정규화 후 https://example.com/b
+		{3, "https://example.com/a", 1},               // 1 이 가져갈 값을 원본으로 가진 나중 기사
+		{4, "http://example.com/b?fbclid=x", nil},     // 2 와 겹침 → NULL
+		{5, "http://[2001:db8::1]:8080/c", nil},       // IPv6
+		{6, "not a url", nil},                         // 정규화 실패 → 그대로
+		{7, "https://example.com/already", nil},       // 이미 정규화된 값
+	}
+	for _, a := range articles {
+		if _, err := db.ExecContext(ctx,
+			"INSERT INTO security_articles (id, title, link, canonical_link, pubDate, cluster_id) VALUES (?, ?, ?, ?, ?, ?)",
+			a.id, "t", a.link, a.link, now, a.cluster,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.ExecContext(ctx,
+		"INSERT INTO story_clusters (id, simhash, representative_id, article_count, first_seen, last_seen) VALUES (1, 0, 3, 2, ?, ?)",
+		now, now,
+	); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{
+		"INSERT INTO search_docs (article_id, length) VALUES (3, 1)",
+		"INSERT INTO search_terms (term, article_id, tf) VALUES ('a', 3, 1)",
+	} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// 두 번 실행해도 결과가 같아야 함
+	for range 2 {
+		if err := backfillCanonical(ctx, conn); err != nil {
+			t.Fatalf("backfillCanonical: %v", err)
+		}
+	}
+	conn.Close()
+
+	// 충돌한 기사는 지우지 않고 NULL 로 남김
+	checkCanonical(t, db, map[int64]string{
+		1: "NULL",
+		2: "https://example.com/b",
+		3: "https://example.com/a",
+		4: "NULL",
+		5: "https://[2001:db8::1]:8080/c",
+		6: "not a url",
+		7: "https://example.com/already",
+	})
+	checkCluster(t, db, 2, 3)
+	checkSearchRows(t, db, 2)
+
+	// 확인만 하면 아무것도 바뀌지 않음
+	dups, err := Dedupe(ctx, db, false)
+	if err != nil {
+		t.Fatalf("Dedupe: %v", err)
+	}
+	want := []Duplicate{
+		{ID: 1, Link: "http://Example.com/a?utm_source=rss", Canonical: "https://example.com/a", Holder: 3, Removed: 3},
+		{ID: 4, Link: "http://example.com/b?fbclid=x", Canonical: "https://example.com/b", Holder: 2, Removed: 4},
+	}
+	if len(dups) != len(want) {
+		t.Fatalf("Dedupe = %+v, want %+v", dups, want)
+	}
+	for i := range want {
+		if dups[i] != want[i] {
+			t.Errorf("Dedupe[%d] = %+v, want %+v", i, dups[i], want[i])
+		}
+	}
+	checkSearchRows(t, db, 2)
+
+	// 적용하면 먼저 저장된 기사만 남기고 지운 기사의 색인과 묶음을 정리
+	if _, err := Dedupe(ctx, db, true); err != nil {
+		t.Fatalf("Dedupe apply: %v", err)
+	}
+	checkCanonical(t, db, map[int64]string{
+		1: "https://example.com/a",
+		2: "https://example.com/b",
+		5: "https://[2001:db8::1]:8080/c",
+		6: "not a url",
+		7: "https://example.com/already",
+	})
+	checkCluster(t, db, 1, 1)
+	checkSearchRows(t, db, 0)
+	if dups, err := Dedupe(ctx, db, true); err != nil || len(dups) != 0 {
+		t.Errorf("다시 Dedupe = %+v, %v, want 없음", dups, err)
+	}
+
+	// down 으로 NOT NULL 스키마로 되돌릴 수 있어야 함
+	if _, err := m.Down(ctx, 1); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+}
+
+func checkCanonical(t *testing.T, db *sql.DB, want map[int64]string) {
+	t.Helper()
+	rows, err := db.Query("SELECT id, COALESCE(canonical_link, 'NULL') FROM security_articles ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	got := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var canonical string
+		if err := rows.Scan(&id, &canonical); err != nil {
+			t.Fatal(err)
+		}
+		got[id] = canonical
+	}
+	if len(got) != len(want) {
+		t.Errorf("남은 기사 %v, want %v", got, want)
+	}
+	for id, c := range want {
+		if got[id] != c {
+			t.Errorf("기사 %d canonical_link = %q, want %q", id, got[id], c)
+		}
+	}
+}
+
+func checkCluster(t *testing.T, db *sql.DB, count, rep int64) {
+	t.Helper()
+	var gotCount, gotRep int64
+	if err := db.QueryRow("SELECT article_count, representative_id FROM story_clusters WHERE id = 1").Scan(&gotCount, &gotRep); err != nil {
+		t.Fatal(err)
+	}
+	if gotCount != count || gotRep != rep {
+		t.Errorf("묶음 article_count=%d representative_id=%d, want %d, %d", gotCount, gotRep, count, rep)
+	}
+}
+
+func checkSearchRows(t *testing.T, db *sql.DB, want int) {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT (SELECT COUNT(*) FROM search_terms) + (SELECT COUNT(*) FROM search_docs)").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != want {
+		t.Errorf("검색 색인 %d행, want %d", n, want)
+	}
+}
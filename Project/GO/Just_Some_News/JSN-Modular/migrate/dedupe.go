@@ -0,0 +1,138 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"jsn-modular/urlnorm"
+)
+
+// querier 는 *sql.Conn 과 *sql.Tx 가 함께 만족하는 메서드입니다.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Duplicate 는 canonical_link 백필에서 충돌해 NULL 로 남은 기사 하나의 처리 내용입니다.
+type Duplicate struct {
+	ID        int64  // canonical_link 가 NULL 인 기사
+	Link      string // 원본 링크
+	Canonical string // 정규화 URL
+	Holder    int64  // 같은 정규화 URL 을 가진 기사, 이제 겹치지 않으면 0
+	Removed   int64  // 합치면서 지우는 기사 (Holder 가 있을 때 둘 중 나중에 저장된 쪽)
+}
+
+// Dedupe 는 canonical_link 가 NULL 인 기사를 찾아 같은 정규화 URL 을 가진 기사와 합칠 계획을 반환합니다.
+// apply 면 트랜잭션 하나 안에서 먼저 저장된(id 가 작은) 기사만 남기고 나머지를 검색 색인,
+// 알림 기록과 함께 지운 뒤 남은 기사에 canonical_link 를 채웁니다. 도중에 실패하면 전체를 되돌립니다.
+func Dedupe(ctx context.Context, db *sql.DB, apply bool) ([]Duplicate, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	type row struct {
+		id   int64
+		link string
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT id, link FROM security_articles WHERE canonical_link IS NULL ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("기사 조회 실패: %w", err)
+	}
+	var pending []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.link); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("기사 조회 실패: %w", err)
+		}
+		pending = append(pending, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("기사 조회 실패: %w", err)
+	}
+
+	// 적용하지 않을 때도 앞에서 채웠을 값을 반영해 같은 결과를 보여 줌
+	claimed := make(map[string]int64)
+	var out []Duplicate
+	for _, r := range pending {
+		canonical, err := urlnorm.Canonical(r.link, "")
+		if err != nil {
+			// 정규화할 수 없는 링크는 백필이 NULL 로 만들지 않음. 원본 링크를 그대로 키로 씀
+			canonical = r.link
+		}
+		d := Duplicate{ID: r.id, Link: r.link, Canonical: canonical}
+		if id, ok := claimed[canonical]; ok {
+			d.Holder = id
+		} else if d.Holder, err = canonicalHolder(ctx, tx, canonical); err != nil {
+			return nil, err
+		}
+
+		keep := r.id
+		if d.Holder != 0 {
+			keep, d.Removed = min(r.id, d.Holder), max(r.id, d.Holder)
+		}
+		claimed[canonical] = keep
+		out = append(out, d)
+		if !apply {
+			continue
+		}
+
+		if d.Removed != 0 {
+			if err := deleteArticle(ctx, tx, d.Removed); err != nil {
+				return nil, err
+			}
+		}
+		if keep == r.id {
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE security_articles SET canonical_link = ? WHERE id = ?", canonical, r.id,
+			); err != nil {
+				return nil, fmt.Errorf("canonical_link 갱신 실패: %w", err)
+			}
+		}
+	}
+
+	if !apply {
+		return out, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("커밋 실패: %w", err)
+	}
+	return out, nil
+}
+
+// deleteArticle 은 중복 기사 하나를 검색 색인, 알림 기록과 함께 지우고 유사 기사 묶음의 기사 수를 줄입니다.
+// 지운 기사가 묶음의 대표였다면 남은 기사 중 가장 먼저 저장된 기사로 바꿉니다.
+func deleteArticle(ctx context.Context, q querier, id int64) error {
+	var cluster sql.NullInt64
+	if err := q.QueryRowContext(ctx, "SELECT cluster_id FROM security_articles WHERE id = ?", id).Scan(&cluster); err != nil {
+		return fmt.Errorf("중복 기사 조회 실패: %w", err)
+	}
+	for _, table := range []string{"search_terms", "search_docs", "alert_deliveries"} {
+		if _, err := q.ExecContext(ctx, "DELETE FROM "+table+" WHERE article_id = ?", id); err != nil {
+			return fmt.Errorf("중복 기사 %s 삭제 실패: %w", table, err)
+		}
+	}
+	if _, err := q.ExecContext(ctx, "DELETE FROM security_articles WHERE id = ?", id); err != nil {
+		return fmt.Errorf("중복 기사 삭제 실패: %w", err)
+	}
+	if !cluster.Valid {
+		return nil
+	}
+	_, err := q.ExecContext(ctx,
+		`UPDATE story_clusters SET article_count = article_count - 1,
+		representative_id = CASE WHEN representative_id = ?
+			THEN COALESCE((SELECT MIN(id) FROM security_articles WHERE cluster_id = ?), representative_id)
+			ELSE representative_id END
+		WHERE id = ?`,
+		id, cluster.Int64, cluster.Int64,
+	)
+	if err != nil {
+		return fmt.Errorf("유사 기사 묶음 갱신 실패: %w", err)
+	}
+	return nil
+}
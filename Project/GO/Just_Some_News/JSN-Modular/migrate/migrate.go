@@ -0,0 +1,346 @@
+// Package migrate applies the embedded, versioned schema migrations for each database dialect.
+// JSN-Monolithic 도 같은 마이그레이션을 적용하도록 internal 밖에 둡니다.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql
+var files embed.FS
+
+// Dialect 는 마이그레이션 SQL 디렉터리 이름이기도 합니다.
+type Dialect string
+
+const (
+	// MySQL 은 MariaDB 용입니다. ADD COLUMN IF NOT EXISTS 같은 MariaDB 전용 DDL 을 써서
+	// MySQL 서버에서는 문법 오류가 나므로 store 는 mariadb:// DSN 만 받습니다.
+	MySQL  Dialect = "mysql"
+	SQLite Dialect = "sqlite"
+)
+
+// lockName 은 MariaDB GET_LOCK 이름입니다. 여러 호스트가 동시에 기동해도 한 곳만 마이그레이션합니다.
+const (
+	lockName    = "jsn_schema_migrations"
+	lockTimeout = 60 // 초
+)
+
+// versionTable 은 적용된 마이그레이션 기록 테이블입니다.
+var versionTable = map[Dialect]string{
+	MySQL: `CREATE TABLE IF NOT EXISTS schema_migrations (
+        version INT PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        applied_at DATETIME NOT NULL
+    ) ENGINE=InnoDB`,
+	SQLite: `CREATE TABLE IF NOT EXISTS schema_migrations (
+        version INTEGER PRIMARY KEY,
+        name TEXT NOT NULL,
+        applied_at DATETIME NOT NULL
+    )`,
+}
+
+// versionTableExists 는 schema_migrations 테이블이 있으면 1 을 반환하는 쿼리입니다.
+var versionTableExists = map[Dialect]string{
+	MySQL:  "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'schema_migrations'",
+	SQLite: "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'",
+}
+
+// Migration 은 버전 하나의 up/down SQL 입니다.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+
+	step func(ctx context.Context, conn *sql.Conn) error // up SQL 뒤에 실행하는 Go 데이터 이관 (dataSteps)
+}
+
+func (m Migration) String() string { return fmt.Sprintf("%04d_%s", m.Version, m.Name) }
+
+// Status 는 마이그레이션 하나의 적용 상태입니다.
+// Known 이 false 면 DB 에는 기록되어 있지만 이 바이너리에는 없는 (더 새로운) 버전입니다.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Known     bool
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load 는 dialect 의 내장 마이그레이션을 버전 순으로 읽습니다.
+func Load(d Dialect) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, string(d))
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 목록 읽기 실패: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("마이그레이션 파일 이름 오류: %s", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(files, string(d)+"/"+e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("마이그레이션 읽기 실패: %w", err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2], step: dataSteps[m[2]]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("마이그레이션 %04d 이름 불일치: %s / %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	var out []Migration
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("마이그레이션 %s 의 up/down 중 하나가 없음", mig)
+		}
+		out = append(out, *mig)
+	}
+	slices.SortFunc(out, func(a, b Migration) int { return a.Version - b.Version })
+	return out, nil
+}
+
+// Migrator 는 한 데이터베이스에 마이그레이션을 적용합니다.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// New 는 dialect 의 내장 마이그레이션으로 Migrator 를 만듭니다.
+func New(db *sql.DB, d Dialect) (*Migrator, error) {
+	migrations, err := Load(d)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: d, migrations: migrations}, nil
+}
+
+// Status 는 내장 마이그레이션과 DB 기록을 합쳐 버전 순으로 반환합니다.
+// 아무것도 만들지 않으므로 조회 권한만 있는 계정으로도 호출할 수 있습니다.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := m.applied(ctx, conn, false)
+	if err != nil {
+		return nil, err
+	}
+	var out []Status
+	for _, mig := range m.migrations {
+		st := Status{Migration: mig, Known: true}
+		if a, ok := applied[mig.Version]; ok {
+			st.Applied, st.AppliedAt = true, a.AppliedAt
+			delete(applied, mig.Version)
+		}
+		out = append(out, st)
+	}
+	for _, a := range applied {
+		out = append(out, a)
+	}
+	slices.SortFunc(out, func(a, b Status) int { return a.Version - b.Version })
+	return out, nil
+}
+
+// Up 은 잠금을 잡고 아직 적용하지 않은 마이그레이션을 버전 순으로 모두 적용합니다.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn, true)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, conn, mig, true); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down 은 잠금을 잡고 가장 최근에 적용된 마이그레이션부터 steps 개를 되돌립니다.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn, true)
+		if err != nil {
+			return err
+		}
+		var versions []int
+		for v := range applied {
+			versions = append(versions, v)
+		}
+		slices.Sort(versions)
+		slices.Reverse(versions)
+
+		for _, v := range versions[:min(steps, len(versions))] {
+			i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version == v })
+			if i < 0 {
+				return fmt.Errorf("이 바이너리에 없는 마이그레이션 %s 은 되돌릴 수 없음", applied[v].Migration)
+			}
+			if err := m.run(ctx, conn, m.migrations[i], false); err != nil {
+				return err
+			}
+			done = append(done, m.migrations[i])
+		}
+		return nil
+	})
+	return done, err
+}
+
+// applied 는 schema_migrations 에 기록된 버전을 읽습니다.
+// 테이블이 없으면 create 면 만들고, 아니면 적용된 버전이 없는 것으로 봅니다.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn, create bool) (map[int]Status, error) {
+	if create {
+		if _, err := conn.ExecContext(ctx, versionTable[m.dialect]); err != nil {
+			return nil, fmt.Errorf("schema_migrations 생성 실패: %w", err)
+		}
+	} else {
+		var n int
+		if err := conn.QueryRowContext(ctx, versionTableExists[m.dialect]).Scan(&n); err != nil {
+			return nil, fmt.Errorf("schema_migrations 조회 실패: %w", err)
+		}
+		if n == 0 {
+			return map[int]Status{}, nil
+		}
+	}
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("schema_migrations 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]Status)
+	for rows.Next() {
+		st := Status{Applied: true}
+		if err := rows.Scan(&st.Version, &st.Name, &st.AppliedAt); err != nil {
+			return nil, fmt.Errorf("schema_migrations 조회 실패: %w", err)
+		}
+		applied[st.Version] = st
+	}
+	return applied, rows.Err()
+}
+
+// run 은 마이그레이션 하나를 실행하고 기록을 남기거나 지웁니다.
+// MariaDB 의 DDL 은 트랜잭션으로 묶이지 않으므로 중간에 실패하면 일부만 적용될 수 있습니다.
+// 그래서 MariaDB 마이그레이션은 IF [NOT] EXISTS 로 작성해 다시 실행해도 안전하게 합니다.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, mig Migration, up bool) error {
+	script, verb := mig.Up, "적용"
+	if !up {
+		script, verb = mig.Down, "되돌리기"
+	}
+	for _, stmt := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("마이그레이션 %s %s 실패: %w", mig, verb, err)
+		}
+	}
+	if up && mig.step != nil {
+		if err := mig.step(ctx, conn); err != nil {
+			return fmt.Errorf("마이그레이션 %s %s 실패: %w", mig, verb, err)
+		}
+	}
+
+	var err error
+	if up {
+		_, err = conn.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+			mig.Version, mig.Name, time.Now().UTC(),
+		)
+	} else {
+		_, err = conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", mig.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("마이그레이션 %s 기록 실패: %w", mig, err)
+	}
+	return nil
+}
+
+// withLock 은 연결 하나를 잡고 마이그레이션 잠금을 얻은 뒤 fn 을 실행합니다.
+// MariaDB 는 GET_LOCK 으로 호스트 간 잠금을 걸고, SQLite 는 BEGIN IMMEDIATE 로
+// 파일 쓰기 잠금을 잡아 전체를 트랜잭션 하나로 실행합니다.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch m.dialect {
+	case MySQL:
+		var got sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&got); err != nil {
+			return fmt.Errorf("마이그레이션 잠금 실패: %w", err)
+		}
+		if got.Int64 != 1 {
+			return fmt.Errorf("마이그레이션 잠금 실패: %d초 동안 다른 호스트가 잠금을 보유", lockTimeout)
+		}
+		defer func() {
+			var released sql.NullInt64
+			_ = conn.QueryRowContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName).Scan(&released)
+		}()
+		return fn(conn)
+
+	case SQLite:
+		if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+			return fmt.Errorf("마이그레이션 잠금 실패: %w", err)
+		}
+		if err := fn(conn); err != nil {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+			return err
+		}
+		if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+			return fmt.Errorf("마이그레이션 커밋 실패: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("지원하지 않는 dialect: %q", m.dialect)
+}
+
+// splitStatements 는 SQL 스크립트를 ; 기준으로 나눕니다. -- 주석 줄은 버립니다.
+// 마이그레이션 SQL 에는 문자열 안의 ; 를 쓰지 않습니다.
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+	var out []string
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			out = append(out, stmt)
+		}
+	}
+	return out
+}
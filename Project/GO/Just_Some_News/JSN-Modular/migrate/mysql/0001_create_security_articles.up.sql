@@ -0,0 +1,9 @@
+-- 모놀리딕 버전부터 쓰던 최초 스키마
+CREATE TABLE IF NOT EXISTS security_articles (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(512) NOT NULL,
+    link VARCHAR(1024) NOT NULL UNIQUE,
+    pubDate DATETIME NOT NULL,
+    description TEXT,
+    collected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_source ON security_articles;
+ALTER TABLE security_articles DROP COLUMN IF EXISTS source;
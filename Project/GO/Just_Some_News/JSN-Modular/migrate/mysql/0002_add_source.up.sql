@@ -0,0 +1,3 @@
+-- 피드별 출처 (config.Feed.Name)
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS source VARCHAR(64) NOT NULL DEFAULT '' AFTER id;
+CREATE INDEX IF NOT EXISTS idx_source ON security_articles (source);
@@ -0,0 +1 @@
+ALTER TABLE security_articles DROP COLUMN IF EXISTS date_guessed;
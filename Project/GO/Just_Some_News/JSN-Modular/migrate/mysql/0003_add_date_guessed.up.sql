@@ -0,0 +1,2 @@
+-- 날짜를 해석하지 못해 수집 시각으로 대체한 기사 표시
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS date_guessed BOOLEAN NOT NULL DEFAULT FALSE AFTER pubDate;
@@ -0,0 +1,8 @@
+-- 피드별 조건부 GET 검증자 (ETag / Last-Modified)
+CREATE TABLE IF NOT EXISTS feed_state (
+    feed VARCHAR(64) PRIMARY KEY,
+    url VARCHAR(1024) NOT NULL,
+    etag VARCHAR(512) NOT NULL DEFAULT '',
+    last_modified VARCHAR(64) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB;
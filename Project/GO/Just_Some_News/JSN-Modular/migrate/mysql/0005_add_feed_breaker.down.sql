@@ -0,0 +1,3 @@
+ALTER TABLE feed_state DROP COLUMN IF EXISTS last_error;
+ALTER TABLE feed_state DROP COLUMN IF EXISTS last_failure_at;
+ALTER TABLE feed_state DROP COLUMN IF EXISTS consecutive_failures;
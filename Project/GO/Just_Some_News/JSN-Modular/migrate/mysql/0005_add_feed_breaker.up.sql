@@ -0,0 +1,4 @@
+-- 서킷 브레이커용 연속 실패 기록
+ALTER TABLE feed_state ADD COLUMN IF NOT EXISTS consecutive_failures INT NOT NULL DEFAULT 0 AFTER last_modified;
+ALTER TABLE feed_state ADD COLUMN IF NOT EXISTS last_failure_at DATETIME NULL AFTER consecutive_failures;
+ALTER TABLE feed_state ADD COLUMN IF NOT EXISTS last_error VARCHAR(512) NOT NULL DEFAULT '' AFTER last_failure_at;
@@ -0,0 +1,3 @@
+ALTER TABLE security_articles DROP COLUMN IF EXISTS content_status;
+ALTER TABLE security_articles DROP COLUMN IF EXISTS word_count;
+ALTER TABLE security_articles DROP COLUMN IF EXISTS content;
@@ -0,0 +1,4 @@
+-- 원문 페이지에서 추출한 본문 (Feed.FullText)
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS content MEDIUMTEXT AFTER description;
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS word_count INT NOT NULL DEFAULT 0 AFTER content;
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS content_status VARCHAR(16) NOT NULL DEFAULT '' AFTER word_count;
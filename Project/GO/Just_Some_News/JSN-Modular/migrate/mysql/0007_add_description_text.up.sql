@@ -0,0 +1,2 @@
+-- 검색/알림용 평문 설명
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS description_text TEXT AFTER description;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uq_canonical_link ON security_articles;
+ALTER TABLE security_articles DROP COLUMN IF EXISTS canonical_link;
@@ -0,0 +1,6 @@
+-- 정규화 URL 을 중복 판정 키로 사용
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS canonical_link VARCHAR(1024) NOT NULL DEFAULT '' AFTER link;
+-- 기존 기사는 원본 링크로 채운 뒤 UNIQUE 인덱스 생성 (link 가 이미 UNIQUE 라 충돌 없음).
+-- 정규화 URL 로 바꾸는 작업은 0014_backfill_canonical_link 가 Go 로 합니다.
+UPDATE security_articles SET canonical_link = link WHERE canonical_link = '';
+CREATE UNIQUE INDEX IF NOT EXISTS uq_canonical_link ON security_articles (canonical_link);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cluster_id ON security_articles;
+ALTER TABLE security_articles DROP COLUMN IF EXISTS cluster_id;
+ALTER TABLE security_articles DROP COLUMN IF EXISTS simhash;
+DROP TABLE IF EXISTS story_clusters;
@@ -0,0 +1,13 @@
+-- 여러 매체가 다룬 같은 이야기(SimHash 근접 중복) 묶음
+CREATE TABLE IF NOT EXISTS story_clusters (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    simhash BIGINT UNSIGNED NOT NULL,
+    representative_id INT NOT NULL,
+    article_count INT NOT NULL DEFAULT 1,
+    first_seen DATETIME NOT NULL,
+    last_seen DATETIME NOT NULL,
+    INDEX idx_last_seen (last_seen)
+) ENGINE=InnoDB;
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS simhash BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER content_status;
+ALTER TABLE security_articles ADD COLUMN IF NOT EXISTS cluster_id INT NULL AFTER simhash;
+CREATE INDEX IF NOT EXISTS idx_cluster_id ON security_articles (cluster_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_pubdate ON security_articles;
@@ -0,0 +1,2 @@
+-- 최신순 조회와 (pubDate, id) 커서 페이지네이션
+CREATE INDEX IF NOT EXISTS idx_pubdate ON security_articles (pubDate, id);
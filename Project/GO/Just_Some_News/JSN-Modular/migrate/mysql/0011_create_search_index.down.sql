@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS search_docs;
+DROP TABLE IF EXISTS search_terms;
@@ -0,0 +1,15 @@
+-- 전문 검색 역색인 (internal/search 의 한글 2-gram + 영문 단어). MariaDB 에는 한국어용
+-- FULLTEXT 파서(ngram)가 없으므로 검색은 이 테이블과 BM25 점수로만 합니다.
+-- 색인어는 이미 소문자이므로 대소문자/악센트를 합치지 않도록 바이너리 정렬을 씁니다.
+CREATE TABLE IF NOT EXISTS search_terms (
+    term VARCHAR(32) NOT NULL,
+    article_id INT NOT NULL,
+    tf INT NOT NULL,
+    PRIMARY KEY (term, article_id),
+    INDEX idx_article (article_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+-- 기사별 가중 길이 (BM25 길이 정규화). 행이 없는 기사는 기동 시 색인합니다.
+CREATE TABLE IF NOT EXISTS search_docs (
+    article_id INT PRIMARY KEY,
+    length INT NOT NULL
+) ENGINE=InnoDB;
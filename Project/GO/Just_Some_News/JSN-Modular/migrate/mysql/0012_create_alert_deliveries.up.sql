@@ -0,0 +1,14 @@
+-- 관심 키워드 알림 전송 기록. (기사, 웹훅) 마다 한 행이라 같은 기사를 두 번 알리지 않습니다.
+-- status: pending(전송 중), sent, failed(다음 수집에서 재시도)
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    article_id INT NOT NULL,
+    webhook VARCHAR(64) NOT NULL,
+    rules VARCHAR(512) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(512) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    sent_at DATETIME NULL,
+    PRIMARY KEY (article_id, webhook),
+    INDEX idx_alert_status (status, created_at)
+) ENGINE=InnoDB;
@@ -0,0 +1,8 @@
+-- 이메일 다이제스트(daily, weekly)가 마지막으로 보낸 수집 시각 구간 [window_start, window_end)
+CREATE TABLE IF NOT EXISTS digest_state (
+    kind VARCHAR(16) PRIMARY KEY,
+    window_start DATETIME NOT NULL,
+    window_end DATETIME NOT NULL,
+    articles INT NOT NULL DEFAULT 0,
+    sent_at DATETIME NOT NULL
+) ENGINE=InnoDB;
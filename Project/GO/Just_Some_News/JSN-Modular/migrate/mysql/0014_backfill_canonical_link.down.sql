@@ -0,0 +1,4 @@
+-- 다시 계산한 canonical_link 는 이전 스키마에서도 유효하므로 그대로 둡니다.
+-- 충돌로 비워 둔 기사는 0008 처럼 원본 링크로 채웁니다 (정규화된 값과 겹치지 않음).
+UPDATE security_articles SET canonical_link = link WHERE canonical_link IS NULL;
+ALTER TABLE security_articles MODIFY canonical_link VARCHAR(1024) NOT NULL DEFAULT '';
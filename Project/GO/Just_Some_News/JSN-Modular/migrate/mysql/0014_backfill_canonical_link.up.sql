@@ -0,0 +1,4 @@
+-- 기존 기사의 canonical_link 를 urlnorm.Canonical 로 다시 계산합니다 (0008 은 원본 링크로 채움).
+-- SQL 로는 정규화할 수 없으므로 실제 작업은 backfill.go 의 backfillCanonical 이 합니다.
+-- 다른 기사와 정규화 URL 이 겹치는 기사는 지우지 않고 NULL 로 남기며, jsn dedupe --apply 로 합칩니다.
+ALTER TABLE security_articles MODIFY canonical_link VARCHAR(1024) NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS story_clusters;
+DROP TABLE IF EXISTS feed_state;
+DROP TABLE IF EXISTS security_articles;
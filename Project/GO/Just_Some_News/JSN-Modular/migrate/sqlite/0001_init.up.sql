@@ -0,0 +1,41 @@
+-- MariaDB 마이그레이션 0001~0010 을 합친 SQLite 초기 스키마
+-- simhash 는 SQLite 정수가 부호 있는 64비트라 비트 그대로 int64 로 저장합니다.
+CREATE TABLE IF NOT EXISTS security_articles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL,
+    link TEXT NOT NULL UNIQUE,
+    canonical_link TEXT NOT NULL DEFAULT '' UNIQUE,
+    pubDate DATETIME NOT NULL,
+    date_guessed BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT,
+    description_text TEXT,
+    content TEXT,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    content_status TEXT NOT NULL DEFAULT '',
+    simhash INTEGER NOT NULL DEFAULT 0,
+    cluster_id INTEGER NULL,
+    collected_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_source ON security_articles (source);
+CREATE INDEX IF NOT EXISTS idx_cluster_id ON security_articles (cluster_id);
+CREATE INDEX IF NOT EXISTS idx_pubdate ON security_articles (pubDate, id);
+CREATE TABLE IF NOT EXISTS feed_state (
+    feed TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at DATETIME NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS story_clusters (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    simhash INTEGER NOT NULL,
+    representative_id INTEGER NOT NULL,
+    article_count INTEGER NOT NULL DEFAULT 1,
+    first_seen DATETIME NOT NULL,
+    last_seen DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_last_seen ON story_clusters (last_seen);
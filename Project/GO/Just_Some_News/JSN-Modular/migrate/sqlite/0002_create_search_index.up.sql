@@ -0,0 +1,12 @@
+-- 전문 검색 역색인 (internal/search 의 한글 2-gram + 영문 단어). 행이 없는 기사는 기동 시 색인합니다.
+CREATE TABLE IF NOT EXISTS search_terms (
+    term TEXT NOT NULL,
+    article_id INTEGER NOT NULL,
+    tf INTEGER NOT NULL,
+    PRIMARY KEY (term, article_id)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS idx_search_terms_article ON search_terms (article_id);
+CREATE TABLE IF NOT EXISTS search_docs (
+    article_id INTEGER PRIMARY KEY,
+    length INTEGER NOT NULL
+);
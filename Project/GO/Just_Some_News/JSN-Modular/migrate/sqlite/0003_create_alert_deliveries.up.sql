@@ -0,0 +1,14 @@
+-- 관심 키워드 알림 전송 기록. (기사, 웹훅) 마다 한 행이라 같은 기사를 두 번 알리지 않습니다.
+-- status: pending(전송 중), sent, failed(다음 수집에서 재시도)
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    article_id INTEGER NOT NULL,
+    webhook TEXT NOT NULL,
+    rules TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    sent_at DATETIME NULL,
+    PRIMARY KEY (article_id, webhook)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS idx_alert_status ON alert_deliveries (status, created_at);
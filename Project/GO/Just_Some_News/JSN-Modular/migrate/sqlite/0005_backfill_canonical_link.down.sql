@@ -0,0 +1,27 @@
+-- 다시 계산한 canonical_link 는 이전 스키마에서도 유효하므로 그대로 둡니다.
+-- 충돌로 비워 둔 기사는 원본 링크로 채운 뒤 NOT NULL 테이블로 다시 만듭니다.
+UPDATE security_articles SET canonical_link = link WHERE canonical_link IS NULL;
+CREATE TABLE security_articles_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL,
+    link TEXT NOT NULL UNIQUE,
+    canonical_link TEXT NOT NULL DEFAULT '' UNIQUE,
+    pubDate DATETIME NOT NULL,
+    date_guessed BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT,
+    description_text TEXT,
+    content TEXT,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    content_status TEXT NOT NULL DEFAULT '',
+    simhash INTEGER NOT NULL DEFAULT 0,
+    cluster_id INTEGER NULL,
+    collected_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO security_articles_new (id, source, title, link, canonical_link, pubDate, date_guessed, description, description_text, content, word_count, content_status, simhash, cluster_id, collected_at)
+    SELECT id, source, title, link, canonical_link, pubDate, date_guessed, description, description_text, content, word_count, content_status, simhash, cluster_id, collected_at FROM security_articles;
+DROP TABLE security_articles;
+ALTER TABLE security_articles_new RENAME TO security_articles;
+CREATE INDEX IF NOT EXISTS idx_source ON security_articles (source);
+CREATE INDEX IF NOT EXISTS idx_cluster_id ON security_articles (cluster_id);
+CREATE INDEX IF NOT EXISTS idx_pubdate ON security_articles (pubDate, id);
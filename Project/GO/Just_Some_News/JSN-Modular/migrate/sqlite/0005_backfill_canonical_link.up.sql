@@ -0,0 +1,28 @@
+-- 기존 기사의 canonical_link 를 urlnorm.Canonical 로 다시 계산합니다 (정규화 규칙이 바뀐 뒤 저장된 값 갱신).
+-- SQL 로는 정규화할 수 없으므로 실제 작업은 backfill.go 의 backfillCanonical 이 합니다.
+-- 다른 기사와 정규화 URL 이 겹치는 기사는 지우지 않고 NULL 로 남기며, jsn dedupe --apply 로 합칩니다.
+-- SQLite 는 컬럼의 NOT NULL 을 바꿀 수 없어 테이블을 다시 만듭니다.
+CREATE TABLE security_articles_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL,
+    link TEXT NOT NULL UNIQUE,
+    canonical_link TEXT UNIQUE,
+    pubDate DATETIME NOT NULL,
+    date_guessed BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT,
+    description_text TEXT,
+    content TEXT,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    content_status TEXT NOT NULL DEFAULT '',
+    simhash INTEGER NOT NULL DEFAULT 0,
+    cluster_id INTEGER NULL,
+    collected_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO security_articles_new (id, source, title, link, canonical_link, pubDate, date_guessed, description, description_text, content, word_count, content_status, simhash, cluster_id, collected_at)
+    SELECT id, source, title, link, canonical_link, pubDate, date_guessed, description, description_text, content, word_count, content_status, simhash, cluster_id, collected_at FROM security_articles;
+DROP TABLE security_articles;
+ALTER TABLE security_articles_new RENAME TO security_articles;
+CREATE INDEX IF NOT EXISTS idx_source ON security_articles (source);
+CREATE INDEX IF NOT EXISTS idx_cluster_id ON security_articles (cluster_id);
+CREATE INDEX IF NOT EXISTS idx_pubdate ON security_articles (pubDate, id);
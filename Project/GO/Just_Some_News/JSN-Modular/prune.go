@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jsn-modular/config"
+)
+
+const pruneUsage = `사용법: jsn prune --older-than 기간
+
+발행 시각이 지금부터 기간보다 오래된 기사를 삭제합니다 (예: --older-than 365d).
+기사가 모두 지워지고 더 이상 갱신되지 않는 유사 기사 묶음도 함께 정리합니다.`
+
+// runPrune 은 jsn prune 을 실행합니다.
+func runPrune(cfg *config.Config, args []string) int {
+	fs := newFlagSet("prune", pruneUsage)
+	var age time.Duration
+	fs.Var(ageValue{&age}, "older-than", "`기간` 보다 오래된 기사 삭제 (예: 90d, 36h, 필수)")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+	if age <= 0 {
+		return usageError(fs, "--older-than 이 필요함")
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer st.Close()
+
+	before := time.Now().Add(-age)
+	n, err := st.Prune(context.Background(), before)
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Printf("%s 이전 기사 %d건 삭제\n", before.Local().Format(time.DateTime), n)
+	return exitOK
+}
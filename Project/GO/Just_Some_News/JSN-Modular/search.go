@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/api"
+	"jsn-modular/internal/search"
+	"jsn-modular/internal/store"
+)
+
+const searchUsage = `사용법: jsn search [--source 이름] [--since 날짜] [--until 날짜] [--limit N] [--offset N] [--json] <검색어...>
+
+제목과 설명을 전문 검색해 관련도 순으로 출력합니다. 검색어를 여러 개 주면 모두 들어간 기사만 찾습니다.
+한글은 두 글자 단위로 색인하므로 "랜섬웨어" 는 "랜섬웨어를", "랜섬웨어가" 도 찾습니다 (한 글자 검색어는 단독으로 쓸 수 없음).
+날짜는 2006-01-02, 2006-01-02 15:04 또는 RFC 3339 형식입니다 (--until 은 미포함).
+
+  jsn search --since 2026-09-01 --until 2026-10-01 랜섬웨어
+  jsn search --source boannews CVE 패치`
+
+// searchSnippetWidth 는 터미널 출력 스니펫의 글자 수입니다.
+const searchSnippetWidth = 100
+
+// runSearch 는 jsn search 를 실행합니다.
+func runSearch(cfg *config.Config, args []string) int {
+	fs := newFlagSet("search", searchUsage)
+	var q store.SearchQuery
+	fs.StringVar(&q.Source, "source", "", "이 출처(피드 이름)의 기사만")
+	fs.Var(timeValue{&q.Since}, "since", "`날짜` 이후 발행된 기사만")
+	fs.Var(timeValue{&q.Until}, "until", "`날짜` 이전 발행된 기사만 (미포함)")
+	fs.IntVar(&q.Limit, "limit", 20, "최대 결과 수")
+	fs.IntVar(&q.Offset, "offset", 0, "앞에서 건너뛸 결과 수")
+	asJSON := fs.Bool("json", false, "JSON 으로 출력 (강조 표시는 HTML <mark>)")
+	terms, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(terms) == 0 {
+		return usageError(fs, "검색어가 필요함")
+	}
+	if q.Limit < 1 {
+		return usageError(fs, "--limit 은 1 이상이어야 함: %d", q.Limit)
+	}
+	if q.Offset < 0 {
+		return usageError(fs, "--offset 은 0 이상이어야 함: %d", q.Offset)
+	}
+	q.Text = strings.Join(terms, " ")
+	sq, err := search.ParseQuery(q.Text)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	st, err := openReadStore(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer st.Close()
+
+	hits, err := st.Search(context.Background(), q)
+	if errors.Is(err, search.ErrShortQuery) {
+		return usageError(fs, "%v", err)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	if *asJSON {
+		out := make([]api.SearchHit, len(hits))
+		for i, h := range hits {
+			out[i] = api.NewSearchHit(h, sq)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(out); err != nil {
+			return fail(err)
+		}
+		return exitOK
+	}
+
+	// 터미널이면 검색어를 굵게 표시
+	on, off := "", ""
+	if isTerminal(os.Stdout) {
+		on, off = "\x1b[1m", "\x1b[0m"
+	}
+	for _, h := range hits {
+		fmt.Printf("%s  [%s] %s  (%.2f)\n", h.PubDate.Local().Format("2006-01-02 15:04"), h.Source,
+			search.Highlight(h.Title, sq, 0).Format(on, off, nil), h.Score)
+		if h.DescriptionText != "" {
+			fmt.Printf("    %s\n", search.Highlight(h.DescriptionText, sq, searchSnippetWidth).Format(on, off, nil))
+		}
+		fmt.Printf("    %s\n", h.Link)
+	}
+	fmt.Fprintf(os.Stderr, "%d건\n", len(hits))
+	return exitOK
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/api"
+	"jsn-modular/internal/logger"
+)
+
+const serveUsage = `사용법: jsn serve [--addr 주소]
+
+수집한 기사를 읽는 HTTP API 서버를 실행합니다. SIGINT/SIGTERM 을 받으면 진행 중인 요청을 마치고 종료합니다.
+
+  GET /healthz              상태 확인
+  GET /api/articles         최신 기사 목록 (본문 제외)
+                            ?source= ?q= ?since= ?until= ?limit= (기본 50, 최대 500) ?cursor=
+                            다음 페이지는 응답의 next_cursor 또는 Link: rel="next" 헤더
+  GET /api/articles/{id}    기사 하나 (본문 포함)
+  GET /api/search           전문 검색, 관련도순 (?q= 필수, ?source= ?since= ?until= ?limit= ?offset=)
+                            검색어를 <mark> 로 강조한 title_html, snippet_html 포함
+  GET /api/sources          출처별 기사 통계
+  GET /feeds/rss            RSS 2.0 재배포 피드, 최신순 (?q= ?source= ?saved= ?limit=)
+  GET /feeds/atom           Atom 1.0 재배포 피드 (예: /feeds/atom?q=CVE&source=boannews)
+                            saved 는 설정 publish.queries 의 이름
+
+since/until 은 2006-01-02, 2006-01-02 15:04 또는 RFC 3339 형식입니다.
+응답에는 ETag 가 붙고, If-None-Match 가 같으면 304 Not Modified 로 답합니다.`
+
+// runServe 는 jsn serve 를 실행합니다.
+func runServe(cfg *config.Config, args []string) int {
+	fs := newFlagSet("serve", serveUsage)
+	addr := fs.String("addr", ":8080", "수신 주소")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+
+	logFile := logger.Setup()
+	defer logFile.Close()
+
+	st, err := openStore(cfg)
+	if err != nil {
+		log.Printf("인프라 초기화 실패: %v", err)
+		return exitCode(err)
+	}
+	defer st.Close()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           api.New(st, cfg.Publish),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	log.Printf(">>> API 서버 시작: %s", *addr)
+
+	select {
+	case err := <-errc:
+		log.Printf("API 서버 실패: %v", err)
+		return exitError
+	case <-ctx.Done():
+	}
+	log.Println(">>> 종료 신호 수신, API 서버 종료 중...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("API 서버 종료 실패: %v", err)
+		return exitError
+	}
+	log.Println(">>> 프로그램 정상 종료.")
+	return exitOK
+}
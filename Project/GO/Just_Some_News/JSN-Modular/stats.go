@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"jsn-modular/config"
+	"jsn-modular/internal/api"
+)
+
+const statsUsage = `사용법: jsn stats [--json]
+
+출처(피드)별 기사 수, 가장 오래된/최근 발행 시각, 마지막 수집 시각을 출력합니다.`
+
+// runStats 는 jsn stats 를 실행합니다.
+func runStats(cfg *config.Config, args []string) int {
+	fs := newFlagSet("stats", statsUsage)
+	asJSON := fs.Bool("json", false, "JSON 으로 출력")
+	pos, code, ok := parseArgs(fs, args)
+	if !ok {
+		return code
+	}
+	if len(pos) > 0 {
+		return usageError(fs, "알 수 없는 인자: %q", pos[0])
+	}
+
+	st, err := openReadStore(cfg)
+	if err != nil {
+		return fail(err)
+	}
+	defer st.Close()
+
+	stats, err := st.Stats(context.Background())
+	if err != nil {
+		return fail(err)
+	}
+
+	if *asJSON {
+		rows := make([]api.Source, len(stats))
+		for i, s := range stats {
+			rows[i] = api.NewSource(s)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fail(err)
+		}
+		return exitOK
+	}
+
+	var total int64
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tARTICLES\tOLDEST\tNEWEST\tLAST COLLECTED")
+	for _, s := range stats {
+		total += s.Articles
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", s.Source, s.Articles,
+			formatTime(s.Oldest), formatTime(s.Newest), formatTime(s.LastCollected))
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t\t\t\n", total)
+	w.Flush()
+	return exitOK
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
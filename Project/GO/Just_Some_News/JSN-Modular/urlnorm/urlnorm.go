@@ -0,0 +1,85 @@
+// Package urlnorm canonicalizes article URLs so the same story under different links dedups.
+// JSN-Monolithic 과 마이그레이션도 같은 정규화를 쓰도록 internal 밖에 둡니다.
+package urlnorm
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams 는 정규화 시 제거하는 추적용 쿼리 파라미터입니다.
+// utm_ 로 시작하는 파라미터는 이름과 관계없이 모두 제거합니다.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"igshid": true, "mc_cid": true, "mc_eid": true, "_hsenc": true, "_hsmi": true,
+}
+
+// Resolve 는 상대 링크를 피드 URL 기준의 절대 URL 로 변환합니다.
+func Resolve(raw, base string) (string, error) {
+	ref, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("URL 파싱 실패: %w", err)
+	}
+	if base != "" && !ref.IsAbs() {
+		b, err := url.Parse(base)
+		if err != nil {
+			return "", fmt.Errorf("기준 URL 파싱 실패: %w", err)
+		}
+		ref = b.ResolveReference(ref)
+	}
+	return ref.String(), nil
+}
+
+// Canonical 은 중복 판정 키로 쓰는 정규화 URL 을 반환합니다.
+// 상대 링크를 base 기준으로 해석한 뒤 스킴을 https 로 통일하고, 호스트를 소문자로,
+// 기본 포트와 fragment, 추적 파라미터, 경로 끝 슬래시를 제거하며 쿼리를 키 순으로 정렬합니다.
+func Canonical(raw, base string) (string, error) {
+	abs, err := Resolve(raw, base)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(abs)
+	if err != nil {
+		return "", fmt.Errorf("URL 파싱 실패: %w", err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("호스트 없는 URL: %q", raw)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme == "http" {
+		// 같은 기사가 http/https 로 번갈아 나오므로 https 로 통일
+		scheme = "https"
+	}
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		// IPv6 리터럴은 포트가 없어도 대괄호로 감쌈
+		host = "[" + host + "]"
+	}
+
+	path := u.EscapedPath()
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+
+	out := scheme + "://" + host + path
+	if len(query) > 0 {
+		// Encode 는 키 순으로 정렬합니다
+		out += "?" + query.Encode()
+	}
+	return out, nil
+}
@@ -0,0 +1,39 @@
+package urlnorm
+
+import "testing"
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		base string
+		want string
+	}{
+		{"http to https and lowercase host", "http://WWW.Example.COM/news/1/", "", "https://www.example.com/news/1"},
+		{"default port dropped", "https://example.com:443/a", "", "https://example.com/a"},
+		{"custom port kept", "https://example.com:8443/a", "", "https://example.com:8443/a"},
+		{"tracking params and fragment", "https://example.com/a?utm_source=x&id=2&fbclid=y#top", "", "https://example.com/a?id=2"},
+		{"query sorted", "https://example.com/a?b=2&a=1", "", "https://example.com/a?a=1&b=2"},
+		{"relative link", "/view.asp?idx=7", "https://www.boannews.com/media/list.asp", "https://www.boannews.com/view.asp?idx=7"},
+		{"IPv6 with port", "http://[2001:DB8::1]:8080/a", "", "https://[2001:db8::1]:8080/a"},
+		{"IPv6 default port", "http://[2001:db8::1]:80/a", "", "https://[2001:db8::1]/a"},
+		{"IPv6 without port", "https://[::1]/", "", "https://[::1]/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Canonical(tt.raw, tt.base)
+			if err != nil {
+				t.Fatalf("Canonical(%q) 오류: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalNoHost(t *testing.T) {
+	if _, err := Canonical("/relative/only", ""); err == nil {
+		t.Error("호스트 없는 URL 에 오류가 없음")
+	}
+}
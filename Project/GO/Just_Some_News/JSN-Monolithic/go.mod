@@ -4,7 +4,15 @@ go 1.25.5
 
 require (
 	github.com/go-sql-driver/mysql v1.9.3
-	golang.org/x/text v0.33.0
+	jsn-modular v0.0.0
 )
 
-require filippo.io/edwards25519 v1.1.0 // indirect
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// 설정 로더, 마이그레이션, URL 정규화, 인코딩 판별을 JSN-Modular 와 공유
+replace jsn-modular => ../JSN-Modular
@@ -7,33 +7,35 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/xml"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql" // MariaDB 드라이버
-	"golang.org/x/text/encoding/korean"
+	"github.com/go-sql-driver/mysql" // MariaDB 드라이버
+	"jsn-modular/config"
+	"jsn-modular/feedcharset"
+	"jsn-modular/migrate"
+	"jsn-modular/urlnorm"
 )
 
 // ==========================================
 // 1. 설정 및 구조체 정의
 // ==========================================
 
-const (
-	DBUser     = "rl"
-	DBPassword = "rockylinux"
-	DBHost     = "192.168.1.46"
-	DBPort     = 3306
-	DBName     = "read_news"
-	RSSURL     = "https://www.boannews.com/media/news_rss.xml"
-)
+// DB 접속 정보와 구독 피드는 JSN-Modular 의 config 패키지로 읽습니다
+// (설정 파일 → JSN_* 환경 변수 → 명령행 플래그). 두 프로그램이 같은 설정 파일을 씁니다.
 
 // RSS XML 구조 정의
 type RSS struct {
@@ -82,75 +84,149 @@ func setupLogger() *os.File {
 // 3. 인프라 및 뉴스 수집 로직
 // ==========================================
 
-func initializeDB() (*sql.DB, error) {
-	// 1. 서버 접속 (DB 미지정)
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?parseTime=true", DBUser, DBPassword, DBHost, DBPort)
-	db, err := sql.Open("mysql", dsn)
+// store 항목이 있으면 JSN-Modular 와 같은 규칙으로 mariadb:// DSN 을 접속 정보로 변환
+// (포트 생략 시 3306, DB 이름 생략 시 read_news). 없으면 db 항목을 그대로 사용
+func storeDB(cfg *config.Config) (config.DB, error) {
+	if cfg.Store == "" {
+		return cfg.DB, nil
+	}
+	u, err := url.Parse(cfg.Store)
 	if err != nil {
-		return nil, err
+		return config.DB{}, fmt.Errorf("store DSN 파싱 실패: %w", err)
+	}
+	if !strings.EqualFold(u.Scheme, "mariadb") {
+		return config.DB{}, fmt.Errorf("모놀리딕 버전은 MariaDB 만 지원합니다 (store 는 mariadb:// DSN, 또는 db 항목 사용)")
 	}
 
-	// 2. DB 및 테이블 생성
-	_, err = db.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s CHARACTER SET utf8mb4", DBName))
+	c := config.DB{
+		User: u.User.Username(),
+		Host: u.Hostname(),
+		Port: 3306,
+		Name: strings.TrimPrefix(u.Path, "/"),
+	}
+	c.Password, _ = u.User.Password()
+	if p := u.Port(); p != "" {
+		if c.Port, err = strconv.Atoi(p); err != nil {
+			return config.DB{}, fmt.Errorf("store DSN 포트 오류: %q", p)
+		}
+	}
+	if c.Name == "" {
+		c.Name = "read_news"
+	}
+	return c, nil
+}
+
+func initializeDB(c config.DB) (*sql.DB, error) {
+	// 1. 서버 접속 (DB 미지정) 후 DB 생성
+	mc := mysql.NewConfig()
+	mc.User, mc.Passwd = c.User, c.Password
+	mc.Net, mc.Addr = "tcp", net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	mc.ParseTime = true
+	// JSN-Modular 와 같이 세션 시간대를 UTC 로 고정해 같은 스키마에 같은 기준으로 시각을 저장
+	mc.Params = map[string]string{"time_zone": "'+00:00'"}
+
+	admin, err := sql.Open("mysql", mc.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	// DB 이름은 백틱으로 감싸고 이름 안의 백틱은 두 번 써서 이스케이프
+	name := "`" + strings.ReplaceAll(c.Name, "`", "``") + "`"
+	_, err = admin.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s CHARACTER SET utf8mb4", name))
+	admin.Close()
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = db.Exec(fmt.Sprintf("USE %s", DBName))
+	// 2. DB 를 DSN 에 지정해 다시 접속 (USE 는 풀의 연결 하나에만 적용됨)
+	mc.DBName = c.Name
+	db, err := sql.Open("mysql", mc.FormatDSN())
 	if err != nil {
 		return nil, err
 	}
 
-	tableQuery := `
-	CREATE TABLE IF NOT EXISTS security_articles (
-		id INT AUTO_INCREMENT PRIMARY KEY,
-		title VARCHAR(512) NOT NULL,
-		link VARCHAR(1024) NOT NULL UNIQUE,
-		pubDate DATETIME NOT NULL,
-		description TEXT,
-		collected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	) ENGINE=InnoDB;`
+	// 3. 적용하지 않은 마이그레이션 실행
+	if err := migrateDB(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
 
-	_, err = db.Exec(tableQuery)
-	return db, err
+// JSN-Modular 와 같은 마이그레이션(migrate 패키지)을 적용합니다. 잠금과 schema_migrations 기록도 공유합니다.
+func migrateDB(db *sql.DB) error {
+	m, err := migrate.New(db, migrate.MySQL)
+	if err != nil {
+		return err
+	}
+	applied, err := m.Up(context.Background())
+	for _, mig := range applied {
+		log.Printf(">>> 스키마 마이그레이션 적용: %s", mig)
+	}
+	return err
 }
 
-func collectNews(db *sql.DB) {
+func collectNews(db *sql.DB, feeds []config.Feed) {
 	log.Println(">>> 뉴스 수집 시작...")
 
+	totalNew, feedCnt := 0, 0
+	for _, feed := range feeds {
+		if !feed.Enabled {
+			log.Printf(">>> [%s] 비활성 피드, 건너뜀", feed.Name)
+			continue
+		}
+		feedCnt++
+
+		newCnt, scanned, err := collectFeed(db, feed)
+		if err != nil {
+			log.Printf(">>> [%s] 수집 실패: %v", feed.Name, err)
+			continue
+		}
+		totalNew += newCnt
+		log.Printf(">>> [%s] 수집 완료: 신규 %d건 / 전체 %d건 스캔", feed.Name, newCnt, scanned)
+	}
+
+	log.Printf(">>> 수집 완료: 피드 %d개 / 신규 %d건", feedCnt, totalNew)
+}
+
+func collectFeed(db *sql.DB, feed config.Feed) (int, int, error) {
 	// 1. HTTP 요청
-	resp, err := http.Get(RSSURL)
+	resp, err := http.Get(feed.URL)
 	if err != nil {
-		log.Printf("RSS 요청 실패: %v", err)
-		return
+		return 0, 0, fmt.Errorf("RSS 요청 실패: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// 2. XML 디코더 생성 (변환되지 않은 원본 resp.Body 사용)
-	decoder := xml.NewDecoder(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, 0, fmt.Errorf("RSS 응답 읽기 실패: %w", err)
+	}
 
-	// [핵심 Fix] XML 헤더의 encoding 선언을 보고 적절한 디코더를 연결해주는 함수
-	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
-		if strings.ToLower(charset) == "euc-kr" {
-			// EUC-KR 리더를 반환하여 디코더가 내부적으로 UTF-8로 읽게 함
-			return korean.EUCKR.NewDecoder().Reader(input), nil
-		}
-		return nil, fmt.Errorf("unsupported charset: %s", charset)
+	// 2. XML 디코더 생성
+	// 인코딩 결정 순서: 피드 설정 > BOM > XML 선언 > HTTP Content-Type > 바이트 스니핑 (JSN-Modular 와 공유)
+	decoder, err := feedcharset.NewDecoder(data, resp.Header.Get("Content-Type"), feed.Charset)
+	if err != nil {
+		return 0, 0, err
 	}
 
 	// 3. XML 파싱
 	var rss RSS
 	// 보안을 위해 필요 시 Strict 모드 조절 가능
 	if err := decoder.Decode(&rss); err != nil {
-		log.Printf("XML 파싱 실패: %v", err)
-		return
+		return 0, 0, fmt.Errorf("XML 파싱 실패: %w", err)
 	}
 
 	newCnt := 0
 	for _, item := range rss.Channel.Items {
+		// JSN-Modular 와 같은 정규화 URL 을 중복 판정 키로 사용
+		canonical, err := urlnorm.Canonical(item.Link, feed.URL)
+		if err != nil {
+			log.Printf(">>> [%s] 링크 정규화 실패: %q: %v", feed.Name, item.Link, err)
+			continue
+		}
+
 		// 중복 확인
 		var exists bool
-		err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM security_articles WHERE link = ?)", item.Link).Scan(&exists)
+		err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM security_articles WHERE link = ? OR canonical_link = ?)", item.Link, canonical).Scan(&exists)
 		if err != nil {
 			log.Printf("DB 조회 에러: %v", err)
 			continue
@@ -165,19 +241,19 @@ func collectNews(db *sql.DB) {
 			}
 
 			_, err = db.Exec(
-				"INSERT INTO security_articles (title, link, pubDate, description) VALUES (?, ?, ?, ?)",
-				item.Title, item.Link, t, item.Description,
+				"INSERT INTO security_articles (source, title, link, canonical_link, pubDate, description) VALUES (?, ?, ?, ?, ?, ?)",
+				feed.Name, item.Title, item.Link, canonical, t, item.Description,
 			)
 			if err != nil {
 				log.Printf("저장 에러: %v", err)
 				continue
 			}
 			newCnt++
-			log.Printf(">>> 신규 수집: %s", item.Title)
+			log.Printf(">>> 신규 수집: [%s] %s", feed.Name, item.Title)
 		}
 	}
 
-	log.Printf(">>> 수집 완료: 신규 %d건 / 전체 %d건 스캔", newCnt, len(rss.Channel.Items))
+	return newCnt, len(rss.Channel.Items), nil
 }
 
 // ==========================================
@@ -185,14 +261,40 @@ func collectNews(db *sql.DB) {
 // ==========================================
 
 func main() {
+	// 설정 읽기 (jsn-mono [플래그] [config print])
+	fs := flag.NewFlagSet("jsn-mono", flag.ExitOnError)
+	loader := config.NewLoader(fs)
+	_ = fs.Parse(os.Args[1:])
+	cfg, err := loader.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if args := fs.Args(); len(args) > 0 {
+		if len(args) != 2 || args[0] != "config" || args[1] != "print" {
+			fmt.Fprintln(os.Stderr, "사용법: jsn-mono [플래그] [config print]")
+			os.Exit(2)
+		}
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	dbCfg, err := storeDB(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "설정 오류: %v\n", err)
+		os.Exit(2)
+	}
+
 	logFile := setupLogger()
 	defer logFile.Close()
 
-	db, err := initializeDB()
+	db, err := initializeDB(dbCfg)
 	if err != nil {
 		log.Fatalf("인프라 초기화 실패: %v", err)
 	}
 	defer db.Close()
 
-	collectNews(db)
+	collectNews(db, cfg.Feeds)
 }